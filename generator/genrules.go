@@ -1,108 +1,134 @@
 package main
 
 import (
-	"bytes"
+	"encoding/json"
 	"fmt"
 	"go/format"
 	"os"
 	"sort"
 	"strings"
-
-	"github.com/PuerkitoBio/goquery"
-	"github.com/levigross/grequests"
 )
 
-const (
-	problems    = "possible-problems"
-	suggestions = "suggestions"
-	lf          = "layout--formatting"
-)
+// rulesFile is a snapshot of the metadata of eslint's core rules, refreshed
+// by script/snapshot-rules.
+const rulesFile = "../generator/eslint-rules.json"
+
+type ruleMeta struct {
+	Type       string   `json:"type"`
+	Deprecated bool     `json:"deprecated"`
+	ReplacedBy []string `json:"replacedBy"`
+	Docs       struct {
+		Recommended bool `json:"recommended"`
+	} `json:"docs"`
+}
+
+type snapshot struct {
+	Version string `json:"version"`
+	Rules   map[string]struct {
+		Meta ruleMeta `json:"meta"`
+	} `json:"rules"`
+}
 
-const rulesURl = "https://eslint.org/docs/latest/rules/"
+// kindOverrides holds kinds of core rules better described by a kind other
+// than the one derived from their type.
+var kindOverrides = map[string]string{
+	"complexity":             "complexity",
+	"max-classes-per-file":   "complexity",
+	"max-depth":              "complexity",
+	"max-lines":              "complexity",
+	"max-lines-per-function": "complexity",
+	"max-nested-callbacks":   "complexity",
+	"max-params":             "complexity",
+	"max-statements":         "complexity",
+	"no-await-in-loop":       "performance",
+	"no-duplicate-imports":   "duplication",
+	"no-eval":                "security",
+	"no-implied-eval":        "security",
+	"no-nested-ternary":      "complexity",
+	"no-new-func":            "security",
+	"no-script-url":          "security",
+}
 
 func main() {
-	rs, err := getRules()
+	s, err := getRules()
 	if err != nil {
 		panic(err)
 	}
 
-	buildFile(rs)
-}
+	buildFile(s)
 
-func getRules() (map[string][]string, error) {
-	resp, err := grequests.Get(rulesURl, nil)
+	plugins, err := getPluginRules()
 	if err != nil {
-		return nil, err
+		panic(err)
 	}
 
-	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(resp.Bytes()))
+	buildPluginFile(plugins)
+}
+
+func getRules() (*snapshot, error) {
+	data, err := os.ReadFile(rulesFile)
 	if err != nil {
 		return nil, err
 	}
 
-	rules := map[string][]string{
-		problems:    {},
-		suggestions: {},
-		lf:          {},
+	s := &snapshot{}
+	if err = json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", rulesFile, err)
+	}
+
+	return s, nil
+}
+
+// kindOf returns the kind of the rule named name. Problems are reported as
+// bugs and layout rules as style issues. Suggestions enabled by
+// eslint:recommended point at likely mistakes and are reported as quality
+// issues, other suggestions as conventions.
+func kindOf(name string, meta ruleMeta) string {
+	if kind, ok := kindOverrides[name]; ok {
+		return kind
 	}
 
-	currentRule := ""
-	doc.Find("h2, a").Each(func(i int, s *goquery.Selection) {
-		switch {
-		case s.Is("h2"):
-			v, ok := s.Attr("id")
-			if ok {
-				if _, ok := rules[v]; ok {
-					currentRule = v
-				}
-			}
-
-		case s.Is("a"):
-			if currentRule == "" {
-				return
-			}
-			c, ok := s.Attr("class")
-			if ok && c == "rule__name" {
-				r := s.Nodes[0].LastChild.Data
-				rules[currentRule] = append(rules[currentRule], r)
-			}
+	switch meta.Type {
+	case "problem":
+		return "bug"
+	case "layout":
+		return "style"
+	case "suggestion":
+		if meta.Docs.Recommended {
+			return "quality"
 		}
-	})
-	return rules, nil
+		return "convention"
+	}
+
+	panic(fmt.Sprintf("unknown type %q of rule %s", meta.Type, name))
 }
 
-func buildFile(ruleMap map[string][]string) {
+func buildFile(s *snapshot) {
 	fileName := "generated_rules.go"
 
-	sortedRuleTypes := []string{lf, problems, suggestions}
+	names := make([]string, 0, len(s.Rules))
+	for name := range s.Rules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
 
 	b := strings.Builder{}
 	b.WriteString("// Code generated by generator/genrules. DO NOT EDIT.\n")
 	b.WriteString("package plugin\n")
 	b.WriteString("import \"github.com/cocov-ci/go-plugin-kit/cocov\"\n")
-	b.WriteString("var rules = map[string]cocov.IssueKind{\n")
-
-	ruleExists := map[string]bool{}
-
-	for _, rType := range sortedRuleTypes {
-		issueKind := ""
-		switch rType {
-		case suggestions:
-			issueKind = "cocov.IssueKindConvention"
-		case problems:
-			issueKind = "cocov.IssueKindBug"
-		case lf:
-			issueKind = "cocov.IssueKindStyle"
+	b.WriteString(fmt.Sprintf("// rules describes the core rules of eslint %s.\n", s.Version))
+	b.WriteString("var rules = map[string]coreRule{\n")
+
+	for _, name := range names {
+		meta := s.Rules[name].Meta
+		b.WriteString(fmt.Sprintf("%q: {kind: %s", name, issueKinds[kindOf(name, meta)]))
+		if meta.Deprecated {
+			b.WriteString(", deprecated: true")
 		}
-
-		rules := ruleMap[rType]
-		sort.Strings(rules)
-		for _, rule := range rules {
-			if ok := ruleExists[rule]; ok {
-				continue
-			}
-			b.WriteString(fmt.Sprintf("\"%s\": %s,\n", rule, issueKind))
+		if len(meta.ReplacedBy) > 0 {
+			b.WriteString(fmt.Sprintf(", replacedBy: %#v", meta.ReplacedBy))
 		}
+		b.WriteString("},\n")
 	}
 
 	b.WriteString("}")
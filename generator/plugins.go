@@ -0,0 +1,124 @@
+package main
+
+import (
+	"fmt"
+	"go/format"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const pluginRulesFile = "../generator/plugin-rules.yaml"
+
+// aliasesKey lists, within a plugin of pluginRulesFile, the further prefixes
+// its rules are referred to by.
+const aliasesKey = "aliases"
+
+var issueKinds = map[string]string{
+	"style":       "cocov.IssueKindStyle",
+	"performance": "cocov.IssueKindPerformance",
+	"security":    "cocov.IssueKindSecurity",
+	"bug":         "cocov.IssueKindBug",
+	"complexity":  "cocov.IssueKindComplexity",
+	"duplication": "cocov.IssueKindDuplication",
+	"convention":  "cocov.IssueKindConvention",
+	"quality":     "cocov.IssueKindQuality",
+}
+
+func getPluginRules() (map[string]map[string][]string, error) {
+	data, err := os.ReadFile(pluginRulesFile)
+	if err != nil {
+		return nil, err
+	}
+
+	plugins := map[string]map[string][]string{}
+	if err = yaml.Unmarshal(data, &plugins); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", pluginRulesFile, err)
+	}
+
+	return plugins, nil
+}
+
+func buildPluginFile(plugins map[string]map[string][]string) {
+	fileName := "generated_plugin_rules.go"
+
+	names := make([]string, 0, len(plugins))
+	for name := range plugins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	aliases := map[string]string{}
+
+	b := strings.Builder{}
+	b.WriteString("// Code generated by generator/genrules. DO NOT EDIT.\n")
+	b.WriteString("package plugin\n")
+	b.WriteString("import \"github.com/cocov-ci/go-plugin-kit/cocov\"\n")
+	b.WriteString("var pluginRules = map[string]map[string]cocov.IssueKind{\n")
+
+	for _, name := range names {
+		ruleKinds := map[string]string{}
+		for kind, rules := range plugins[name] {
+			if kind == aliasesKey {
+				for _, alias := range rules {
+					if other, ok := aliases[alias]; ok {
+						panic(fmt.Sprintf("alias %s of %s is also an alias of %s", alias, name, other))
+					}
+					aliases[alias] = name
+				}
+				continue
+			}
+
+			issueKind, ok := issueKinds[kind]
+			if !ok {
+				panic(fmt.Sprintf("unknown kind %s of plugin %s", kind, name))
+			}
+
+			for _, rule := range rules {
+				if _, ok := ruleKinds[rule]; ok {
+					panic(fmt.Sprintf("rule %s/%s is listed more than once", name, rule))
+				}
+				ruleKinds[rule] = issueKind
+			}
+		}
+
+		rules := make([]string, 0, len(ruleKinds))
+		for rule := range ruleKinds {
+			rules = append(rules, rule)
+		}
+		sort.Strings(rules)
+
+		b.WriteString(fmt.Sprintf("%q: {\n", name))
+		for _, rule := range rules {
+			b.WriteString(fmt.Sprintf("%q: %s,\n", rule, ruleKinds[rule]))
+		}
+		b.WriteString("},\n")
+	}
+	b.WriteString("}\n")
+
+	aliasNames := make([]string, 0, len(aliases))
+	for alias := range aliases {
+		if _, ok := plugins[alias]; ok {
+			panic(fmt.Sprintf("alias %s of %s is also a plugin", alias, aliases[alias]))
+		}
+		aliasNames = append(aliasNames, alias)
+	}
+	sort.Strings(aliasNames)
+
+	b.WriteString("var pluginAliases = map[string]string{\n")
+	for _, alias := range aliasNames {
+		b.WriteString(fmt.Sprintf("%q: %q,\n", alias, aliases[alias]))
+	}
+	b.WriteString("}")
+
+	src, err := format.Source([]byte(b.String()))
+	if err != nil {
+		panic(err)
+	}
+
+	if err = os.WriteFile(fileName, src, os.ModePerm); err != nil {
+		panic(err)
+	}
+}
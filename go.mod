@@ -4,17 +4,16 @@ go 1.19
 
 require (
 	github.com/Masterminds/semver v1.5.0
-	github.com/PuerkitoBio/goquery v1.8.1
 	github.com/cocov-ci/go-plugin-kit v0.1.21
 	github.com/golang/mock v1.6.0
 	github.com/heyvito/httpie v0.1.1
 	github.com/levigross/grequests v0.0.0-20221222020224-9eee758d18d5
 	github.com/stretchr/testify v1.8.2
 	go.uber.org/zap v1.24.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
-	github.com/andybalholm/cascadia v1.3.1 // indirect
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/google/go-cmp v0.5.9 // indirect
 	github.com/google/go-querystring v1.1.0 // indirect
@@ -24,5 +23,4 @@ require (
 	go.uber.org/atomic v1.10.0 // indirect
 	go.uber.org/multierr v1.11.0 // indirect
 	golang.org/x/net v0.9.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,100 @@
+package plugin
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/cocov-ci/go-plugin-kit/cocov"
+	"go.uber.org/zap"
+)
+
+// toolVersion returns the output of `cmd --version`, ran with opts.
+func toolVersion(ctx cocov.Context, e Exec, cmd string, opts *cocov.ExecOpts) (string, error) {
+	stdOut, stdErr, err := e.Exec2(cmd, []string{"--version"}, opts)
+	if err != nil {
+		ctx.L().Error("failed to determine version",
+			zap.String("command", cmd),
+			zap.String("std err", string(stdErr)),
+			zap.Error(err),
+		)
+		return "", err
+	}
+
+	return strings.TrimSpace(string(stdOut)), nil
+}
+
+// nodeModulesCacheKeys returns the artifact cache keys of the dependencies
+// installed at repoPath. Keys are derived from the contents of package.json
+// and the lockfile, along with everything else affecting installed
+// packages: the Node and package manager versions, the platform and the
+// install settings.
+func nodeModulesCacheKeys(ctx cocov.Context, e Exec, manager, managerVersion, file, repoPath string, settings installSettings, opts *cocov.ExecOpts) ([]string, error) {
+	platform, err := detectPlatform()
+	if err != nil {
+		ctx.L().Error("failed to detect platform", zap.Error(err))
+		return nil, err
+	}
+
+	nodeVersion, err := toolVersion(ctx, e, "node", opts)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := []string{
+		"node_modules",
+		"platform=" + platform,
+		"node=" + nodeVersion,
+		manager + "=" + managerVersion,
+	}
+
+	if settings.scope == scopeLintOnly {
+		keys = append(keys, "scope=lint-only")
+	}
+
+	if settings.ignoreScripts {
+		keys = append(keys, "ignore-scripts")
+	}
+
+	files := []string{pkgJson}
+	if file != "" {
+		files = append(files, file)
+	}
+
+	for _, name := range files {
+		sum, err := fileSHA256(filepath.Join(repoPath, name))
+		if err != nil {
+			ctx.L().Error("failed to hash file",
+				zap.String("file", name),
+				zap.Error(err),
+			)
+			return nil, err
+		}
+		keys = append(keys, fmt.Sprintf("%s=%s", name, sum))
+	}
+
+	return keys, nil
+}
+
+// pnpmStore returns the location of pnpm's content-addressable store along
+// with its artifact cache keys. The store is shared by every package using
+// the same pnpm version, regardless of their dependencies.
+func pnpmStore(ctx cocov.Context, e Exec, managerVersion string, opts *cocov.ExecOpts) (string, []string, error) {
+	stdOut, stdErr, err := e.Exec2(pnpm, []string{"store", "path"}, opts)
+	if err != nil {
+		ctx.L().Error("failed to determine pnpm store path",
+			zap.String("std err", string(stdErr)),
+			zap.Error(err),
+		)
+		return "", nil, err
+	}
+
+	platform, err := detectPlatform()
+	if err != nil {
+		ctx.L().Error("failed to detect platform", zap.Error(err))
+		return "", nil, err
+	}
+
+	keys := []string{"pnpm-store", "platform=" + platform, pnpm + "=" + managerVersion}
+	return strings.TrimSpace(string(stdOut)), keys, nil
+}
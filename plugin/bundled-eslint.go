@@ -0,0 +1,67 @@
+package plugin
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cocov-ci/go-plugin-kit/cocov"
+	"go.uber.org/zap"
+)
+
+const bundledEslintRoot = "/cocov/eslint"
+
+// installedEslints holds the bundled eslint installations already prepared
+// during the current run.
+var installedEslints = map[string]bool{}
+
+// installBundledEslint installs the provided eslint version into a directory
+// under root, shared by every project lacking eslint, and returns the path
+// to its executable.
+func installBundledEslint(ctx cocov.Context, e Exec, nodePath, version, root string) (string, error) {
+	if strings.ContainsAny(version, `/\ `) {
+		err := fmt.Errorf("invalid value %q for %s", version, envEslintVersion)
+		ctx.L().Error("invalid bundled eslint version", zap.Error(err))
+		return "", err
+	}
+
+	key := "eslint-" + version
+	installPath := filepath.Join(root, key)
+	bin := filepath.Join(installPath, "node_modules", ".bin", "eslint")
+
+	if installedEslints[installPath] {
+		return bin, nil
+	}
+
+	if ok := ctx.LoadToolCache(key, installPath); ok {
+		installedEslints[installPath] = true
+		return bin, nil
+	}
+
+	if err := os.MkdirAll(installPath, os.ModePerm); err != nil {
+		ctx.L().Error("error creating directory",
+			zap.String("path", installPath),
+			zap.Error(err),
+		)
+		return "", err
+	}
+
+	ctx.L().Info("installing bundled eslint", zap.String("version", version))
+	args := []string{"install", "--prefix", installPath, "--no-save", "--no-package-lock", "eslint@" + version}
+	opts := &cocov.ExecOpts{Env: map[string]string{"PATH": nodePath}}
+	stdOut, stdErr, err := e.Exec2(npm, args, opts)
+	if err != nil {
+		ctx.L().Error("failed to install bundled eslint",
+			zap.String("std out", string(stdOut)),
+			zap.String("std err", string(stdErr)),
+			zap.Error(err),
+		)
+		return "", err
+	}
+
+	ctx.StoreToolCache(key, installPath)
+	installedEslints[installPath] = true
+
+	return bin, nil
+}
@@ -0,0 +1,95 @@
+package plugin
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/cocov-ci/go-plugin-kit/cocov"
+	"go.uber.org/zap"
+)
+
+const shasumsFile = "SHASUMS256.txt"
+
+var errChecksumMismatch = errors.New("node archive checksum mismatch")
+
+// verifyChecksum checks the SHA-256 digest of the archive at filePath against
+// the one listed for archive in the SHASUMS256.txt file at sumsURL.
+func verifyChecksum(ctx cocov.Context, sumsURL, archive, filePath string) error {
+	sums, err := fetch(sumsURL)
+	if err != nil {
+		ctx.L().Error("failed to retrieve node checksums", zap.Error(err))
+		return err
+	}
+
+	expected, err := findChecksum(bytes.NewReader(sums), archive)
+	if err != nil {
+		ctx.L().Error("failed to read node checksums", zap.Error(err))
+		return err
+	}
+
+	actual, err := fileSHA256(filePath)
+	if err != nil {
+		ctx.L().Error("failed to compute node archive checksum", zap.Error(err))
+		return err
+	}
+
+	if !strings.EqualFold(expected, actual) {
+		_ = os.Remove(filePath)
+		ctx.L().Error("node archive checksum mismatch",
+			zap.String("archive", archive),
+			zap.String("expected", expected),
+			zap.String("actual", actual),
+		)
+		return fmt.Errorf("%w: %s: expected %s, got %s",
+			errChecksumMismatch, archive, expected, actual)
+	}
+
+	ctx.L().Info("verified node archive checksum", zap.String("archive", archive))
+
+	return nil
+}
+
+// findChecksum looks for the digest of fileName in a SHASUMS256.txt file,
+// whose lines are composed of a digest followed by a file name.
+func findChecksum(r io.Reader, fileName string) (string, error) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+
+		if strings.TrimPrefix(fields[1], "*") == fileName {
+			return fields[0], nil
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+
+	return "", fmt.Errorf("checksum for %s not found in %s", fileName, shasumsFile)
+}
+
+func fileSHA256(filePath string) (string, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+
+	defer func() { _ = f.Close() }()
+
+	h := sha256.New()
+	if _, err = io.Copy(h, f); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,82 @@
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/heyvito/httpie"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyChecksum(t *testing.T) {
+	archive := "node-v18.16.0-linux-x64.tar.gz"
+	contents := []byte("node archive")
+	digest := "63c1d0cd9b49ae6c615d3e3121d7c7dc31be0441b35fb13b489e64e6797de6cc"
+
+	writeArchive := func(t *testing.T) string {
+		p := filepath.Join(t.TempDir(), "node.tar.gz")
+		require.NoError(t, os.WriteFile(p, contents, os.ModePerm))
+		return p
+	}
+
+	serveSums := func(sums string) *httpie.Server {
+		return httpie.New(httpie.WithCustom("/v18.16.0/SHASUMS256.txt", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(sums))
+		}))
+	}
+
+	t.Run("Fails to retrieve checksums", func(t *testing.T) {
+		server := httpie.New(httpie.WithCustom("/", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Stop()
+
+		helper := newTestHelper(t)
+		url := server.URL + "/v18.16.0/SHASUMS256.txt"
+
+		err := verifyChecksum(helper.ctx, url, archive, writeArchive(t))
+		assert.Error(t, err)
+	})
+
+	t.Run("Fails when the archive is not listed", func(t *testing.T) {
+		server := serveSums(digest + "  node-v18.16.0-linux-arm64.tar.gz\n")
+		defer server.Stop()
+
+		helper := newTestHelper(t)
+		url := server.URL + "/v18.16.0/SHASUMS256.txt"
+
+		err := verifyChecksum(helper.ctx, url, archive, writeArchive(t))
+		assert.ErrorContains(t, err, "not found")
+	})
+
+	t.Run("Fails on mismatch", func(t *testing.T) {
+		sums := "0000000000000000000000000000000000000000000000000000000000000000  " + archive + "\n"
+		server := serveSums(sums)
+		defer server.Stop()
+
+		helper := newTestHelper(t)
+		url := server.URL + "/v18.16.0/SHASUMS256.txt"
+		p := writeArchive(t)
+
+		err := verifyChecksum(helper.ctx, url, archive, p)
+		assert.True(t, errors.Is(err, errChecksumMismatch))
+		assert.NoFileExists(t, p)
+	})
+
+	t.Run("Works as expected", func(t *testing.T) {
+		sums := fmt.Sprintf("%s  node-v18.16.0-linux-arm64.tar.gz\n%s  %s\n", digest, digest, archive)
+		server := serveSums(sums)
+		defer server.Stop()
+
+		helper := newTestHelper(t)
+		url := server.URL + "/v18.16.0/SHASUMS256.txt"
+
+		err := verifyChecksum(helper.ctx, url, archive, writeArchive(t))
+		assert.NoError(t, err)
+	})
+}
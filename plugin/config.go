@@ -0,0 +1,321 @@
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cocov-ci/go-plugin-kit/cocov"
+	"gopkg.in/yaml.v3"
+)
+
+// Settings are read from environment variables, which can be provided to the
+// plugin through the envs section of .cocov.yaml.
+const (
+	envDefaultNodeVersion = "COCOV_ESLINT_DEFAULT_NODE_VERSION"
+	envNodeVersionPolicy  = "COCOV_ESLINT_NODE_VERSION_POLICY"
+	envNodeMirror         = "COCOV_ESLINT_NODE_MIRROR"
+	envNodeIndexURL       = "COCOV_ESLINT_NODE_INDEX_URL"
+	envNodeDownloadURL    = "COCOV_ESLINT_NODE_DOWNLOAD_URL"
+	envNodeChecksumsURL   = "COCOV_ESLINT_NODE_CHECKSUMS_URL"
+	envNodeIndexTTL       = "COCOV_ESLINT_NODE_INDEX_TTL"
+	envInstallMode        = "COCOV_ESLINT_INSTALL_MODE"
+	envIgnoreScripts      = "COCOV_ESLINT_IGNORE_SCRIPTS"
+	envInstallScope       = "COCOV_ESLINT_INSTALL_SCOPE"
+	envEslintVersion      = "COCOV_ESLINT_VERSION"
+	envWarnings           = "COCOV_ESLINT_WARNINGS"
+)
+
+const defaultNodeIndexTTL = 24 * time.Hour
+
+const (
+	defaultNodeMirror = "https://nodejs.org/dist"
+	// Official builds are not provided for musl, which are published by
+	// the unofficial-builds project instead.
+	defaultMuslNodeMirror = "https://unofficial-builds.nodejs.org/download/release"
+)
+
+func getSetting(name string) string {
+	return strings.TrimSpace(os.Getenv(name))
+}
+
+// nodeDefault is the Node version used by packages that do not pin one.
+type nodeDefault struct {
+	version string
+	// setting names where version was read from.
+	setting string
+	// announced reports whether the use of version was already logged.
+	announced bool
+}
+
+// loadNodeDefault resolves the default Node version of the repository at
+// rootPath, read from COCOV_ESLINT_DEFAULT_NODE_VERSION or, in its absence,
+// from the default_node_version field of .cocov/eslint.yaml. Besides ranges
+// and nvm aliases, "latest LTS" is accepted as a synonym for lts/*.
+func loadNodeDefault(rootPath string) (*nodeDefault, error) {
+	def := &nodeDefault{version: getSetting(envDefaultNodeVersion), setting: envDefaultNodeVersion}
+
+	if def.version == "" {
+		data, err := os.ReadFile(filepath.Join(rootPath, configFile))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+
+		file := struct {
+			DefaultNodeVersion string `yaml:"default_node_version"`
+		}{}
+		if err = yaml.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", configFile, err)
+		}
+
+		def.version = strings.TrimSpace(file.DefaultNodeVersion)
+		def.setting = filepath.ToSlash(configFile)
+	}
+
+	if strings.EqualFold(def.version, "latest lts") {
+		def.version = "lts/*"
+	}
+
+	return def, nil
+}
+
+// bundledEslintVersion returns the eslint version installed by the plugin
+// for projects in which eslint cannot be resolved, or an empty string in case
+// projects must provide their own.
+func bundledEslintVersion() string {
+	return getSetting(envEslintVersion)
+}
+
+// versionPolicy determines which of the versions satisfying a range is
+// installed.
+type versionPolicy int
+
+const (
+	// policyNewestLTS selects the newest LTS release, falling back to the
+	// newest release when no LTS release satisfies the range.
+	policyNewestLTS versionPolicy = iota
+	// policyNewest selects the newest release, regardless of its LTS status.
+	policyNewest
+	// policyOldest selects the oldest release.
+	policyOldest
+)
+
+var versionPolicies = map[string]versionPolicy{
+	"lts":    policyNewestLTS,
+	"newest": policyNewest,
+	"oldest": policyOldest,
+}
+
+// nodeVersionPolicy returns the configured version policy, defaulting to
+// policyNewestLTS.
+func nodeVersionPolicy() (versionPolicy, error) {
+	v := strings.ToLower(getSetting(envNodeVersionPolicy))
+	if v == "" {
+		return policyNewestLTS, nil
+	}
+
+	p, ok := versionPolicies[v]
+	if !ok {
+		return 0, fmt.Errorf("unknown value %q for %s. supported are: lts, newest, oldest", v, envNodeVersionPolicy)
+	}
+
+	return p, nil
+}
+
+// nodeMirror returns the base location of the Node distribution for the given
+// platform, which may either be an HTTP(S) URL, a file:// URL or a local
+// directory laid out like https://nodejs.org/dist.
+func nodeMirror(platform string) string {
+	v := getSetting(envNodeMirror)
+	if v == "" {
+		if isMuslPlatform(platform) {
+			return defaultMuslNodeMirror
+		}
+		return defaultNodeMirror
+	}
+
+	return strings.TrimSuffix(v, "/")
+}
+
+// nodeIndexURL returns the location of the version index, which defaults to
+// index.json in the configured mirror.
+func nodeIndexURL(platform string) string {
+	if v := getSetting(envNodeIndexURL); v != "" {
+		return v
+	}
+
+	return nodeMirror(platform) + "/index.json"
+}
+
+// nodeDownloadTemplate returns the template used to build the location of
+// Node archives. The {version} and {platform} placeholders are replaced by
+// values such as v18.16.0 and linux-x64, respectively.
+func nodeDownloadTemplate(platform string) string {
+	if v := getSetting(envNodeDownloadURL); v != "" {
+		return v
+	}
+
+	return nodeMirror(platform) + "/{version}/node-{version}-{platform}.tar.gz"
+}
+
+// nodeChecksumsTemplate returns the template used to build the location of
+// the SHASUMS256.txt file listing the digests of Node archives, using the
+// same placeholders as nodeDownloadTemplate. It defaults to the layout of
+// the configured mirror, regardless of the download template, and must be
+// set along with the download template for servers laid out differently.
+func nodeChecksumsTemplate(platform string) string {
+	if v := getSetting(envNodeChecksumsURL); v != "" {
+		return v
+	}
+
+	return nodeMirror(platform) + "/{version}/" + shasumsFile
+}
+
+// nodeIndexTTL returns for how long a cached copy of the version index may be
+// used. Setting it to zero disables the cache.
+func nodeIndexTTL() (time.Duration, error) {
+	v := getSetting(envNodeIndexTTL)
+	if v == "" {
+		return defaultNodeIndexTTL, nil
+	}
+
+	ttl, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q for %s: %w", v, envNodeIndexTTL, err)
+	}
+
+	return ttl, nil
+}
+
+// installMode determines how dependencies are installed.
+type installMode int
+
+const (
+	// modeInstall runs a plain install, which may update lockfiles.
+	modeInstall installMode = iota
+	// modeFrozen installs exactly what lockfiles describe, failing when
+	// they are out of date.
+	modeFrozen
+)
+
+var installModes = map[string]installMode{
+	"install": modeInstall,
+	"frozen":  modeFrozen,
+}
+
+// dependencyInstallMode returns the configured install mode, defaulting to
+// modeInstall.
+func dependencyInstallMode() (installMode, error) {
+	v := strings.ToLower(getSetting(envInstallMode))
+	if v == "" {
+		return modeInstall, nil
+	}
+
+	m, ok := installModes[v]
+	if !ok {
+		return 0, fmt.Errorf("unknown value %q for %s. supported are: install, frozen", v, envInstallMode)
+	}
+
+	return m, nil
+}
+
+// installScope determines which dependencies are installed.
+type installScope int
+
+const (
+	// scopeAll installs every dependency of the project.
+	scopeAll installScope = iota
+	// scopeLintOnly installs only the dependencies required to run eslint.
+	scopeLintOnly
+)
+
+var installScopes = map[string]installScope{
+	"all":       scopeAll,
+	"lint-only": scopeLintOnly,
+}
+
+// dependencyInstallScope returns the configured install scope, defaulting
+// to scopeAll.
+func dependencyInstallScope() (installScope, error) {
+	v := strings.ToLower(getSetting(envInstallScope))
+	if v == "" {
+		return scopeAll, nil
+	}
+
+	s, ok := installScopes[v]
+	if !ok {
+		return 0, fmt.Errorf("unknown value %q for %s. supported are: all, lint-only", v, envInstallScope)
+	}
+
+	return s, nil
+}
+
+// ignoreScripts reports whether lifecycle scripts of dependencies must be
+// skipped during installs.
+func ignoreScripts() (bool, error) {
+	v := getSetting(envIgnoreScripts)
+	if v == "" {
+		return false, nil
+	}
+
+	ok, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, fmt.Errorf("invalid value %q for %s: %w", v, envIgnoreScripts, err)
+	}
+
+	return ok, nil
+}
+
+var issueKinds = map[string]cocov.IssueKind{
+	"style":       cocov.IssueKindStyle,
+	"performance": cocov.IssueKindPerformance,
+	"security":    cocov.IssueKindSecurity,
+	"bug":         cocov.IssueKindBug,
+	"complexity":  cocov.IssueKindComplexity,
+	"duplication": cocov.IssueKindDuplication,
+	"convention":  cocov.IssueKindConvention,
+	"quality":     cocov.IssueKindQuality,
+}
+
+// warningPolicy determines how messages of rules configured as "warn" are
+// reported.
+type warningPolicy struct {
+	// drop discards warnings altogether.
+	drop bool
+	// kind, when set, replaces the kind of the rule that reported the
+	// warning.
+	kind *cocov.IssueKind
+}
+
+// warningsPolicy returns the configured warning policy. Warnings are reported
+// just like errors by default, and may also be dropped or reported under a
+// given kind, such as style.
+func warningsPolicy() (warningPolicy, error) {
+	v := strings.ToLower(getSetting(envWarnings))
+	switch v {
+	case "", "include":
+		return warningPolicy{}, nil
+	case "drop":
+		return warningPolicy{drop: true}, nil
+	}
+
+	kind, ok := issueKinds[v]
+	if !ok {
+		names := make([]string, 0, len(issueKinds))
+		for k := range issueKinds {
+			names = append(names, k)
+		}
+		sort.Strings(names)
+
+		return warningPolicy{}, fmt.Errorf("unknown value %q for %s. supported are: include, drop, %s",
+			v, envWarnings, strings.Join(names, ", "))
+	}
+
+	return warningPolicy{kind: &kind}, nil
+}
@@ -1,16 +1,161 @@
 package plugin
 
-import "github.com/Masterminds/semver"
+import (
+	"strconv"
+	"strings"
 
-type constraints []*semver.Constraints
+	"github.com/Masterminds/semver"
+)
 
+// constraints represents a node-semver range: a list of comparator sets
+// joined by ||, of which at least one must be satisfied.
+type constraints []comparatorSet
+
+// eval reports whether any of the provided versions satisfies the range.
 func (c constraints) eval(versions ...*semver.Version) bool {
 	for _, v := range versions {
-		for _, constraint := range c {
-			if constraint.Check(v) {
+		for _, set := range c {
+			if set.check(v) {
 				return true
 			}
 		}
 	}
 	return false
 }
+
+// comparatorSet holds comparators that must all be satisfied by a version.
+type comparatorSet []comparator
+
+func (s comparatorSet) check(v *semver.Version) bool {
+	for _, c := range s {
+		if !c.check(v) {
+			return false
+		}
+	}
+
+	if v.Prerelease() == "" {
+		return true
+	}
+
+	// Just like node-semver, prereleases are only accepted when one of the
+	// comparators of the set refers to a prerelease of the same version.
+	for _, c := range s {
+		if c.any || len(c.version.pre) == 0 {
+			continue
+		}
+
+		if c.version.major == v.Major() && c.version.minor == v.Minor() && c.version.patch == v.Patch() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// comparator is a primitive comparison against a version. Comparators with
+// any set are satisfied by every version.
+type comparator struct {
+	op      string
+	version rangeVersion
+	any     bool
+}
+
+func (c comparator) check(v *semver.Version) bool {
+	if c.any {
+		return true
+	}
+
+	cmp := compareVersion(v, c.version)
+	switch c.op {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	default:
+		return cmp == 0
+	}
+}
+
+type rangeVersion struct {
+	major, minor, patch int64
+	pre                 []string
+}
+
+// compareVersion compares v against r following the precedence rules of the
+// semver specification.
+func compareVersion(v *semver.Version, r rangeVersion) int {
+	for _, p := range [][2]int64{
+		{v.Major(), r.major},
+		{v.Minor(), r.minor},
+		{v.Patch(), r.patch},
+	} {
+		if p[0] < p[1] {
+			return -1
+		}
+		if p[0] > p[1] {
+			return 1
+		}
+	}
+
+	var pre []string
+	if v.Prerelease() != "" {
+		pre = strings.Split(v.Prerelease(), ".")
+	}
+
+	return comparePrerelease(pre, r.pre)
+}
+
+func comparePrerelease(a, b []string) int {
+	switch {
+	case len(a) == 0 && len(b) == 0:
+		return 0
+	case len(a) == 0:
+		return 1
+	case len(b) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if d := compareIdentifier(a[i], b[i]); d != 0 {
+			return d
+		}
+	}
+
+	switch {
+	case len(a) < len(b):
+		return -1
+	case len(a) > len(b):
+		return 1
+	}
+
+	return 0
+}
+
+// compareIdentifier compares prerelease identifiers. Numeric identifiers are
+// compared numerically, and always have lower precedence than alphanumeric
+// ones.
+func compareIdentifier(a, b string) int {
+	an, aErr := strconv.ParseUint(a, 10, 64)
+	bn, bErr := strconv.ParseUint(b, 10, 64)
+
+	switch {
+	case aErr == nil && bErr == nil:
+		switch {
+		case an < bn:
+			return -1
+		case an > bn:
+			return 1
+		}
+		return 0
+	case aErr == nil:
+		return -1
+	case bErr == nil:
+		return 1
+	}
+
+	return strings.Compare(a, b)
+}
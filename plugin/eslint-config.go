@@ -0,0 +1,118 @@
+package plugin
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// flatConfigFiles lists configuration files in the flat format, the default
+// since eslint 9.
+var flatConfigFiles = []string{
+	"eslint.config.js",
+	"eslint.config.mjs",
+	"eslint.config.cjs",
+	"eslint.config.ts",
+	"eslint.config.mts",
+	"eslint.config.cts",
+}
+
+// legacyConfigFiles lists configuration files in the legacy (eslintrc)
+// format, in the order of precedence used by eslint.
+var legacyConfigFiles = []string{
+	".eslintrc.js",
+	".eslintrc.cjs",
+	".eslintrc.yaml",
+	".eslintrc.yml",
+	".eslintrc.json",
+	".eslintrc",
+}
+
+// eslintConfig is the configuration file used to lint a project.
+type eslintConfig struct {
+	path string
+	flat bool
+}
+
+// findEslintConfig looks for the eslint configuration of the project at
+// repoPath, in it and its parents up to rootPath. Just like eslint, flat
+// configurations take precedence over legacy ones regardless of their depth.
+// It returns nil in case no configuration is found.
+func findEslintConfig(rootPath, repoPath string) (*eslintConfig, error) {
+	dirs := []string{filepath.Clean(repoPath)}
+	for dir := dirs[0]; dir != filepath.Clean(rootPath); {
+		parent := filepath.Dir(dir)
+		if parent == dir || !isWithin(rootPath, parent) {
+			break
+		}
+		dirs = append(dirs, parent)
+		dir = parent
+	}
+
+	for _, dir := range dirs {
+		file, err := firstExisting(dir, flatConfigFiles)
+		if err != nil || file != "" {
+			return &eslintConfig{path: file, flat: true}, err
+		}
+	}
+
+	for _, dir := range dirs {
+		file, err := firstExisting(dir, legacyConfigFiles)
+		if err != nil || file != "" {
+			return &eslintConfig{path: file}, err
+		}
+
+		ok, err := hasPackageJsonConfig(dir)
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			return &eslintConfig{path: filepath.Join(dir, pkgJson)}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// firstExisting returns the path of the first of names present in dir, or an
+// empty string in case none of them is.
+func firstExisting(dir string, names []string) (string, error) {
+	for _, name := range names {
+		file := filepath.Join(dir, name)
+		_, err := os.Stat(file)
+		if err == nil {
+			return file, nil
+		}
+
+		if !errors.Is(err, fs.ErrNotExist) {
+			return "", err
+		}
+	}
+
+	return "", nil
+}
+
+// hasPackageJsonConfig reports whether the package.json file in dir holds a
+// legacy configuration in its eslintConfig field.
+func hasPackageJsonConfig(dir string) (bool, error) {
+	data, err := os.ReadFile(filepath.Join(dir, pkgJson))
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	pkg := struct {
+		EslintConfig json.RawMessage `json:"eslintConfig"`
+	}{}
+	if err = json.Unmarshal(data, &pkg); err != nil {
+		return false, err
+	}
+
+	return len(pkg.EslintConfig) > 0 && string(pkg.EslintConfig) != "null", nil
+}
@@ -0,0 +1,66 @@
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindEslintConfig(t *testing.T) {
+	write := func(t *testing.T, path, content string) {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+		require.NoError(t, os.WriteFile(path, []byte(content), os.ModePerm))
+	}
+
+	t.Run("Finds flat configurations", func(t *testing.T) {
+		root := t.TempDir()
+		write(t, filepath.Join(root, "eslint.config.mjs"), "export default []")
+
+		cfg, err := findEslintConfig(root, root)
+		require.NoError(t, err)
+		assert.Equal(t, &eslintConfig{path: filepath.Join(root, "eslint.config.mjs"), flat: true}, cfg)
+	})
+
+	t.Run("Finds legacy configurations", func(t *testing.T) {
+		root := t.TempDir()
+		write(t, filepath.Join(root, ".eslintrc.json"), "{}")
+
+		cfg, err := findEslintConfig(root, root)
+		require.NoError(t, err)
+		assert.Equal(t, &eslintConfig{path: filepath.Join(root, ".eslintrc.json")}, cfg)
+	})
+
+	t.Run("Finds configurations in package.json", func(t *testing.T) {
+		root := t.TempDir()
+		write(t, filepath.Join(root, pkgJson), `{"eslintConfig": {"extends": "eslint:recommended"}}`)
+
+		cfg, err := findEslintConfig(root, root)
+		require.NoError(t, err)
+		assert.Equal(t, &eslintConfig{path: filepath.Join(root, pkgJson)}, cfg)
+	})
+
+	t.Run("Prefers flat configurations of parents", func(t *testing.T) {
+		root := t.TempDir()
+		pkg := filepath.Join(root, "packages", "app")
+		write(t, filepath.Join(root, "eslint.config.js"), "module.exports = []")
+		write(t, filepath.Join(pkg, ".eslintrc.yml"), "root: true")
+
+		cfg, err := findEslintConfig(root, pkg)
+		require.NoError(t, err)
+		assert.Equal(t, &eslintConfig{path: filepath.Join(root, "eslint.config.js"), flat: true}, cfg)
+	})
+
+	t.Run("Does not leave the root path", func(t *testing.T) {
+		parent := t.TempDir()
+		root := filepath.Join(parent, "repo")
+		write(t, filepath.Join(parent, "eslint.config.js"), "module.exports = []")
+		write(t, filepath.Join(root, pkgJson), `{}`)
+
+		cfg, err := findEslintConfig(root, root)
+		require.NoError(t, err)
+		assert.Nil(t, cfg)
+	})
+}
@@ -3,23 +3,58 @@ package plugin
 import (
 	"encoding/json"
 	"fmt"
+	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"time"
 
 	"github.com/cocov-ci/go-plugin-kit/cocov"
 	"go.uber.org/zap"
 )
 
-func runEslint(ctx cocov.Context, e Exec, nodePath, repoPath string) (*cliOutput, error) {
-	eslintPath := filepath.Join(repoPath, "node_modules", ".bin", "eslint")
-	args := []string{"-f", "json-with-metadata", "--quiet", repoPath}
+func runEslint(ctx cocov.Context, e Exec, manager, nodePath string, p project) (*cliOutput, error) {
+	eslintPath, prefix, ok := eslintCommand(manager, p)
+	if !ok {
+		version := bundledEslintVersion()
+		if version == "" {
+			ctx.L().Error(errNoEslintDep.Error(), zap.String("path", p.path))
+			return nil, errNoEslintDep
+		}
+
+		ctx.L().Info("eslint not found in project, using bundled eslint",
+			zap.String("version", version),
+			zap.String("path", p.path),
+		)
+
+		var err error
+		if eslintPath, err = installBundledEslint(ctx, e, nodePath, version, bundledEslintRoot); err != nil {
+			return nil, err
+		}
+	}
+
+	args := append(prefix, "-f", "json-with-metadata")
+	for _, member := range p.members {
+		// Workspace members are linted on their own.
+		rel, err := filepath.Rel(p.path, member)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, "--ignore-pattern", filepath.ToSlash(rel)+"/")
+	}
+	args = append(args, p.path)
 
-	ctx.L().Info("Running eslint")
+	ctx.L().Info("Running eslint", zap.String("path", p.path))
 	start := time.Now()
 
 	envs := map[string]string{"PATH": nodePath}
-	opts := &cocov.ExecOpts{Env: envs}
+	if p.config != nil {
+		// Both eslint 8 and 9 accept either format when told which one
+		// is in use.
+		envs["ESLINT_USE_FLAT_CONFIG"] = strconv.FormatBool(p.config.flat)
+	}
+	opts := &cocov.ExecOpts{Workdir: p.path, Env: envs}
+
 	stdOut, stdErr, err := e.Exec2(eslintPath, args, opts)
 	if err != nil {
 		if execErr, ok := err.(*exec.ExitError); ok {
@@ -55,3 +90,63 @@ func runEslint(ctx cocov.Context, e Exec, nodePath, repoPath string) (*cliOutput
 
 	return out, nil
 }
+
+// eslintCommand returns the command used to run eslint for p, and whether
+// eslint could be resolved at all. The node_modules/.bin shim of the project
+// is preferred, followed by the one of its workspace root, where
+// dependencies are usually hoisted to, and by the eslint package itself.
+// Projects lacking them, such as those using yarn Plug'n'Play, run eslint
+// through their package manager when it is declared as a dependency.
+func eslintCommand(manager string, p project) (string, []string, bool) {
+	dirs := []string{p.path}
+	if p.root != p.path {
+		dirs = append(dirs, p.root)
+	}
+
+	for _, dir := range dirs {
+		shim := filepath.Join(dir, "node_modules", ".bin", "eslint")
+		if _, err := os.Stat(shim); err == nil {
+			return shim, nil, true
+		}
+	}
+
+	for _, dir := range dirs {
+		script := filepath.Join(dir, "node_modules", "eslint", "bin", "eslint.js")
+		if _, err := os.Stat(script); err == nil {
+			return "node", []string{script}, true
+		}
+	}
+
+	if manager != yarn && manager != bun {
+		return "", nil, false
+	}
+
+	for _, dir := range dirs {
+		if declaresDependency(dir, "eslint") {
+			if manager == yarn {
+				return yarn, []string{"eslint"}, true
+			}
+			return "bunx", []string{"eslint"}, true
+		}
+	}
+
+	return "", nil, false
+}
+
+// declaresDependency reports whether the package.json file in dir declares
+// name as a dependency or development dependency.
+func declaresDependency(dir, name string) bool {
+	data, err := os.ReadFile(filepath.Join(dir, pkgJson))
+	if err != nil {
+		return false
+	}
+
+	pkg := packageJson{}
+	if err = json.Unmarshal(data, &pkg); err != nil {
+		return false
+	}
+
+	_, dep := pkg.Deps[name]
+	_, devDep := pkg.DevDeps[name]
+	return dep || devDep
+}
@@ -2,6 +2,7 @@ package plugin
 
 import (
 	"errors"
+	"os"
 	"path/filepath"
 	"testing"
 
@@ -10,12 +11,19 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+func writeEslintShim(t *testing.T, dir string) string {
+	bin := filepath.Join(dir, "node_modules", ".bin")
+	require.NoError(t, os.MkdirAll(bin, os.ModePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(bin, "eslint"), nil, os.ModePerm))
+	return filepath.Join(bin, "eslint")
+}
+
 func TestRunEslint(t *testing.T) {
-	wd := "workdir"
+	wd := t.TempDir()
 	np := "node-path"
-	eslintPath := filepath.Join(wd, "node_modules", ".bin", "eslint")
-	args := []string{"-f", "json-with-metadata", "--quiet", wd}
-	opts := &cocov.ExecOpts{Env: map[string]string{"PATH": np}}
+	eslintPath := writeEslintShim(t, wd)
+	args := []string{"-f", "json-with-metadata", wd}
+	opts := &cocov.ExecOpts{Workdir: wd, Env: map[string]string{"PATH": np}}
 
 	t.Run("Fails running eslint", func(t *testing.T) {
 		helper := newTestHelper(t)
@@ -28,7 +36,7 @@ func TestRunEslint(t *testing.T) {
 			Exec2(eslintPath, args, opts).
 			Return(stdOut, stdErr, boom)
 
-		_, err := runEslint(helper.ctx, helper.exec, np, wd)
+		_, err := runEslint(helper.ctx, helper.exec, npm, np, project{path: wd, root: wd})
 		require.Error(t, err)
 	})
 
@@ -42,7 +50,7 @@ func TestRunEslint(t *testing.T) {
 			Exec2(eslintPath, args, opts).
 			Return(stdOut, stdErr, nil)
 
-		_, err := runEslint(helper.ctx, helper.exec, np, wd)
+		_, err := runEslint(helper.ctx, helper.exec, npm, np, project{path: wd, root: wd})
 		require.Error(t, err)
 		assert.ErrorContains(t, err, "json")
 	})
@@ -56,8 +64,173 @@ func TestRunEslint(t *testing.T) {
 			Exec2(eslintPath, args, opts).
 			Return(stdOut, nil, nil)
 
-		out, err := runEslint(helper.ctx, helper.exec, np, wd)
+		out, err := runEslint(helper.ctx, helper.exec, npm, np, project{path: wd, root: wd})
+		require.NoError(t, err)
+		assert.NotNil(t, out)
+	})
+
+	t.Run("Runs through yarn without a .bin shim", func(t *testing.T) {
+		helper := newTestHelper(t)
+		dir := t.TempDir()
+		pkg := []byte(`{"devDependencies": {"eslint": "^8.0.0"}}`)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, pkgJson), pkg, os.ModePerm))
+
+		yarnArgs := []string{"eslint", "-f", "json-with-metadata", dir}
+		yarnOpts := &cocov.ExecOpts{Workdir: dir, Env: map[string]string{"PATH": np}}
+
+		helper.exec.EXPECT().
+			Exec2(yarn, yarnArgs, yarnOpts).
+			Return(validOutput(t), nil, nil)
+
+		out, err := runEslint(helper.ctx, helper.exec, yarn, np, project{path: dir, root: dir})
 		require.NoError(t, err)
 		assert.NotNil(t, out)
 	})
+
+	t.Run("Ignores workspace members", func(t *testing.T) {
+		helper := newTestHelper(t)
+		p := project{
+			path:    wd,
+			root:    wd,
+			members: []string{filepath.Join(wd, "packages", "app")},
+		}
+
+		wsArgs := []string{"-f", "json-with-metadata", "--ignore-pattern", "packages/app/", wd}
+		helper.exec.EXPECT().
+			Exec2(eslintPath, wsArgs, opts).
+			Return(validOutput(t), nil, nil)
+
+		out, err := runEslint(helper.ctx, helper.exec, npm, np, p)
+		require.NoError(t, err)
+		assert.NotNil(t, out)
+	})
+
+	t.Run("Sets the configuration format", func(t *testing.T) {
+		helper := newTestHelper(t)
+		p := project{path: wd, root: wd, config: &eslintConfig{path: filepath.Join(wd, ".eslintrc.json")}}
+
+		legacyOpts := &cocov.ExecOpts{Workdir: wd, Env: map[string]string{
+			"PATH":                   np,
+			"ESLINT_USE_FLAT_CONFIG": "false",
+		}}
+		helper.exec.EXPECT().
+			Exec2(eslintPath, args, legacyOpts).
+			Return(validOutput(t), nil, nil)
+
+		_, err := runEslint(helper.ctx, helper.exec, npm, np, p)
+		require.NoError(t, err)
+	})
+
+	t.Run("Fails without eslint", func(t *testing.T) {
+		helper := newTestHelper(t)
+		dir := t.TempDir()
+
+		_, err := runEslint(helper.ctx, helper.exec, npm, np, project{path: dir, root: dir})
+		assert.ErrorIs(t, err, errNoEslintDep)
+	})
+
+	t.Run("Uses the bundled eslint", func(t *testing.T) {
+		t.Setenv(envEslintVersion, "8.57.0")
+		helper := newTestHelper(t)
+		dir := t.TempDir()
+		installPath := filepath.Join(bundledEslintRoot, "eslint-8.57.0")
+		t.Cleanup(func() { delete(installedEslints, installPath) })
+
+		bundledArgs := []string{"-f", "json-with-metadata", dir}
+		bundledOpts := &cocov.ExecOpts{Workdir: dir, Env: map[string]string{"PATH": np}}
+
+		helper.ctx.EXPECT().LoadToolCache("eslint-8.57.0", installPath).Return(true)
+		helper.exec.EXPECT().
+			Exec2(filepath.Join(installPath, "node_modules", ".bin", "eslint"), bundledArgs, bundledOpts).
+			Return(validOutput(t), nil, nil)
+
+		out, err := runEslint(helper.ctx, helper.exec, npm, np, project{path: dir, root: dir})
+		require.NoError(t, err)
+		assert.NotNil(t, out)
+	})
+}
+
+func TestInstallBundledEslint(t *testing.T) {
+	np := "node-path"
+
+	t.Run("Installs through npm", func(t *testing.T) {
+		helper := newTestHelper(t)
+		root := t.TempDir()
+		installPath := filepath.Join(root, "eslint-8.57.0")
+		t.Cleanup(func() { delete(installedEslints, installPath) })
+
+		args := []string{"install", "--prefix", installPath, "--no-save", "--no-package-lock", "eslint@8.57.0"}
+		helper.ctx.EXPECT().LoadToolCache("eslint-8.57.0", installPath).Return(false)
+		helper.exec.EXPECT().
+			Exec2(npm, args, &cocov.ExecOpts{Env: map[string]string{"PATH": np}}).
+			Return(nil, nil, nil)
+		helper.ctx.EXPECT().StoreToolCache("eslint-8.57.0", installPath)
+
+		bin, err := installBundledEslint(helper.ctx, helper.exec, np, "8.57.0", root)
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(installPath, "node_modules", ".bin", "eslint"), bin)
+
+		// Further projects reuse the installation
+		_, err = installBundledEslint(helper.ctx, helper.exec, np, "8.57.0", root)
+		require.NoError(t, err)
+	})
+
+	t.Run("Rejects paths", func(t *testing.T) {
+		helper := newTestHelper(t)
+		_, err := installBundledEslint(helper.ctx, helper.exec, np, "../8", t.TempDir())
+		assert.Error(t, err)
+	})
+}
+
+func TestEslintCommand(t *testing.T) {
+	t.Run("Prefers the .bin shim", func(t *testing.T) {
+		dir := t.TempDir()
+		shim := writeEslintShim(t, dir)
+
+		cmd, args, ok := eslintCommand(yarn, project{path: dir, root: dir})
+		assert.True(t, ok)
+		assert.Equal(t, shim, cmd)
+		assert.Empty(t, args)
+	})
+
+	t.Run("Falls back to the workspace root shim", func(t *testing.T) {
+		root := t.TempDir()
+		member := filepath.Join(root, "packages", "app")
+		require.NoError(t, os.MkdirAll(member, os.ModePerm))
+		shim := writeEslintShim(t, root)
+
+		cmd, args, ok := eslintCommand(npm, project{path: member, root: root})
+		assert.True(t, ok)
+		assert.Equal(t, shim, cmd)
+		assert.Empty(t, args)
+	})
+
+	t.Run("Resolves transitive eslint packages", func(t *testing.T) {
+		dir := t.TempDir()
+		script := filepath.Join(dir, "node_modules", "eslint", "bin", "eslint.js")
+		require.NoError(t, os.MkdirAll(filepath.Dir(script), os.ModePerm))
+		require.NoError(t, os.WriteFile(script, nil, os.ModePerm))
+
+		cmd, args, ok := eslintCommand(npm, project{path: dir, root: dir})
+		assert.True(t, ok)
+		assert.Equal(t, "node", cmd)
+		assert.Equal(t, []string{script}, args)
+	})
+
+	t.Run("Uses bunx", func(t *testing.T) {
+		dir := t.TempDir()
+		pkg := []byte(`{"dependencies": {"eslint": "^8.0.0"}}`)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, pkgJson), pkg, os.ModePerm))
+
+		cmd, args, ok := eslintCommand(bun, project{path: dir, root: dir})
+		assert.True(t, ok)
+		assert.Equal(t, "bunx", cmd)
+		assert.Equal(t, []string{"eslint"}, args)
+	})
+
+	t.Run("Does not resolve undeclared eslint", func(t *testing.T) {
+		dir := t.TempDir()
+		_, _, ok := eslintCommand(yarn, project{path: dir, root: dir})
+		assert.False(t, ok)
+	})
 }
@@ -0,0 +1,89 @@
+package plugin
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/levigross/grequests"
+)
+
+// localPath returns the path on disk referred by location when it is either
+// a file:// URL or an absolute path.
+func localPath(location string) (string, bool) {
+	if strings.HasPrefix(location, "file://") {
+		u, err := url.Parse(location)
+		if err != nil {
+			return strings.TrimPrefix(location, "file://"), true
+		}
+		return u.Path, true
+	}
+
+	if filepath.IsAbs(location) {
+		return location, true
+	}
+
+	return "", false
+}
+
+// fetch retrieves the contents of location, which may either be an HTTP(S)
+// URL, a file:// URL or an absolute path.
+func fetch(location string) ([]byte, error) {
+	if p, ok := localPath(location); ok {
+		return os.ReadFile(p)
+	}
+
+	resp, err := grequests.Get(location, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Close()
+
+	if !resp.Ok {
+		return nil, fmt.Errorf("failed to retrieve %s: status %d", location, resp.StatusCode)
+	}
+
+	return resp.Bytes(), nil
+}
+
+// fetchToFile behaves like fetch, but writes the retrieved contents to dst.
+func fetchToFile(location, dst string) error {
+	p, ok := localPath(location)
+	if !ok {
+		resp, err := grequests.Get(location, nil)
+		if err != nil {
+			return err
+		}
+
+		defer resp.Close()
+
+		if !resp.Ok {
+			return fmt.Errorf("failed to retrieve %s: status %d", location, resp.StatusCode)
+		}
+
+		return resp.DownloadToFile(dst)
+	}
+
+	src, err := os.Open(p)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = src.Close() }()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+
+	if _, err = io.Copy(out, src); err != nil {
+		_ = out.Close()
+		return err
+	}
+
+	return out.Close()
+}
@@ -0,0 +1,91 @@
+package plugin
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cocov-ci/go-plugin-kit/cocov"
+)
+
+// fingerprinter builds issue IDs that remain stable across commits. IDs are
+// derived from the rule, the file and the source lines an issue spans, so
+// that they survive changes elsewhere in the file, including line shifts.
+type fingerprinter struct {
+	root string
+
+	// sources holds the lines of files already read.
+	sources map[string][]string
+	// seen counts the occurrences of each fingerprint, telling apart
+	// identical issues of a single file.
+	seen map[string]int
+}
+
+func newFingerprinter(root string) *fingerprinter {
+	return &fingerprinter{
+		root:    root,
+		sources: map[string][]string{},
+		seen:    map[string]int{},
+	}
+}
+
+// fingerprint returns the ID of the issue reported by m on the file at
+// path, relative to the root of the repository.
+func (f *fingerprinter) fingerprint(path string, m message) string {
+	rule := m.RuleID
+	if rule == "" && m.Fatal {
+		rule = "fatal"
+	}
+
+	base := strings.Join([]string{
+		rule,
+		m.severityName(),
+		path,
+		normalizeSnippet(f.snippet(path, m.Line, m.EndLine)),
+	}, "\x00")
+
+	occurrence := f.seen[base]
+	f.seen[base]++
+
+	return cocov.SHA1([]byte(fmt.Sprintf("%s\x00%d", base, occurrence)))
+}
+
+// snippet returns the lines between start and end of the file at path, both
+// 1-based and inclusive. Lines out of range, and unreadable files, yield no
+// lines.
+func (f *fingerprinter) snippet(path string, start, end uint) []string {
+	lines, ok := f.sources[path]
+	if !ok {
+		data, err := os.ReadFile(filepath.Join(f.root, filepath.FromSlash(path)))
+		if err == nil {
+			lines = strings.Split(string(data), "\n")
+		}
+		f.sources[path] = lines
+	}
+
+	if end < start {
+		end = start
+	}
+
+	if start == 0 || int(start) > len(lines) {
+		return nil
+	}
+
+	if int(end) > len(lines) {
+		end = uint(len(lines))
+	}
+
+	return lines[start-1 : end]
+}
+
+// normalizeSnippet collapses whitespace within lines, so that indentation
+// and formatting changes do not affect fingerprints.
+func normalizeSnippet(lines []string) string {
+	normalized := make([]string, 0, len(lines))
+	for _, l := range lines {
+		normalized = append(normalized, strings.Join(strings.Fields(l), " "))
+	}
+
+	return strings.Join(normalized, "\n")
+}
@@ -0,0 +1,58 @@
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFingerprint(t *testing.T) {
+	root := t.TempDir()
+	file := "index.js"
+	write := func(content string) {
+		require.NoError(t, os.WriteFile(filepath.Join(root, file), []byte(content), os.ModePerm))
+	}
+
+	semi := message{RuleID: "semi", Severity: severityError, Line: 2, EndLine: 2}
+	quotes := message{RuleID: "quotes", Severity: severityError, Line: 2, EndLine: 2}
+
+	write("const a = 1;\nconst b = \"2\"\n")
+	first := newFingerprinter(root).fingerprint(file, semi)
+
+	t.Run("Survives line shifts and reformatting", func(t *testing.T) {
+		write("// header\n\nconst a = 1;\n    const   b = \"2\"\n")
+		moved := semi
+		moved.Line, moved.EndLine = 4, 4
+
+		assert.Equal(t, first, newFingerprinter(root).fingerprint(file, moved))
+	})
+
+	t.Run("Distinguishes rules on the same line", func(t *testing.T) {
+		write("const a = 1;\nconst b = \"2\"\n")
+		fp := newFingerprinter(root)
+
+		assert.NotEqual(t, fp.fingerprint(file, semi), fp.fingerprint(file, quotes))
+	})
+
+	t.Run("Distinguishes identical occurrences", func(t *testing.T) {
+		write("const a = 1;\nfoo()\nfoo()\n")
+		fp := newFingerprinter(root)
+		second := semi
+		second.Line, second.EndLine = 3, 3
+
+		assert.NotEqual(t, fp.fingerprint(file, semi), fp.fingerprint(file, second))
+	})
+
+	t.Run("Changes with the offending source", func(t *testing.T) {
+		write("const a = 1;\nconst c = \"2\"\n")
+		assert.NotEqual(t, first, newFingerprinter(root).fingerprint(file, semi))
+	})
+
+	t.Run("Handles missing files", func(t *testing.T) {
+		fp := newFingerprinter(root)
+		assert.NotEmpty(t, fp.fingerprint("missing.js", semi))
+	})
+}
@@ -2,6 +2,7 @@ package plugin
 
 import (
 	"encoding/json"
+	"fmt"
 	"github.com/cocov-ci/go-plugin-kit/cocov"
 	"go.uber.org/zap"
 	"io/fs"
@@ -25,7 +26,7 @@ func findRepositories(rootPath string) ([]string, error) {
 			}
 
 			if d.Name() == "package.json" {
-				repos = append(repos, filepath.Dir(path))
+				repos = append(repos, filepath.Join(rootPath, filepath.Dir(path)))
 			}
 			return nil
 		})
@@ -37,39 +38,115 @@ func findRepositories(rootPath string) ([]string, error) {
 	return repos, nil
 }
 
-func checkDependencies(ctx cocov.Context, repoPath string) (string, error) {
-	jsonFile := filepath.Join(repoPath, pkgJson)
-	f, err := os.ReadFile(jsonFile)
+// relativePath returns filePath relative to rootPath, using forward
+// slashes. Symlinks are resolved whenever possible, so that files are
+// reported under their actual location in the repository, regardless of the
+// links either path went through. Paths outside rootPath are rejected.
+func relativePath(rootPath, filePath string) (string, error) {
+	if !filepath.IsAbs(filePath) {
+		filePath = filepath.Join(rootPath, filePath)
+	}
+
+	resolvedRoot, rootErr := filepath.EvalSymlinks(rootPath)
+	resolvedFile, fileErr := filepath.EvalSymlinks(filePath)
+	if rootErr == nil && fileErr == nil && isWithin(resolvedRoot, resolvedFile) {
+		rootPath, filePath = resolvedRoot, resolvedFile
+	}
+
+	if !isWithin(rootPath, filePath) {
+		return "", fmt.Errorf("%s is outside of %s", filePath, rootPath)
+	}
+
+	rel, err := filepath.Rel(rootPath, filePath)
 	if err != nil {
-		ctx.L().Error("failed to read package.json", zap.Error(err))
 		return "", err
 	}
 
-	pkg := struct {
-		Engines struct {
-			Node string `json:"node"`
-		} `json:"engines"`
+	return filepath.ToSlash(rel), nil
+}
+
+type packageJson struct {
+	Engines struct {
+		Node string `json:"node"`
+	} `json:"engines"`
+
+	Volta struct {
+		Node string `json:"node"`
+	} `json:"volta"`
+
+	PackageManager string `json:"packageManager"`
+
+	Deps    map[string]string `json:"dependencies"`
+	DevDeps map[string]string `json:"devDependencies"`
+}
 
-		Deps    map[string]string `json:"dependencies"`
-		DevDeps map[string]string `json:"devDependencies"`
-	}{}
+func readPackageJson(ctx cocov.Context, repoPath string) (*packageJson, error) {
+	jsonFile := filepath.Join(repoPath, pkgJson)
+	f, err := os.ReadFile(jsonFile)
+	if err != nil {
+		ctx.L().Error("failed to read package.json", zap.Error(err))
+		return nil, err
+	}
 
+	pkg := packageJson{}
 	if err = json.Unmarshal(f, &pkg); err != nil {
 		ctx.L().Error("failed to unmarshall package.json", zap.Error(err))
+		return nil, err
+	}
+
+	return &pkg, nil
+}
+
+// checkDependencies returns the Node version required by p. Workspace
+// members lacking a version of their own use the one of their workspace
+// root, and packages pinning no version at all use the default one, if any.
+func checkDependencies(ctx cocov.Context, p project, def *nodeDefault) (string, error) {
+	pkg, err := readPackageJson(ctx, p.path)
+	if err != nil {
 		return "", err
 	}
 
-	nodeVersion := pkg.Engines.Node
-	if nodeVersion == "" {
-		ctx.L().Error(errNoVersionFound.Error())
-		return "", errNoVersionFound
+	nodeVersion, source, err := findNodeVersion(p.path, pkg)
+	if err != nil {
+		ctx.L().Error("failed to read node version", zap.Error(err))
+		return "", err
+	}
+
+	if nodeVersion == "" && p.root != p.path {
+		rootPkg, err := readPackageJson(ctx, p.root)
+		if err != nil {
+			return "", err
+		}
+
+		nodeVersion, source, err = findNodeVersion(p.root, rootPkg)
+		if err != nil {
+			ctx.L().Error("failed to read node version", zap.Error(err))
+			return "", err
+		}
+		source = "workspace root " + source
 	}
 
-	eslintKey := "eslint"
-	if _, ok := pkg.Deps[eslintKey]; !ok {
-		if _, ok = pkg.DevDeps[eslintKey]; !ok {
-			return "", errNoEslintDep
+	switch {
+	case nodeVersion != "":
+		ctx.L().Info("using node version",
+			zap.String("version", nodeVersion),
+			zap.String("source", source),
+		)
+
+	case def.version != "":
+		nodeVersion = def.version
+		if !def.announced {
+			def.announced = true
+			ctx.L().Info("no node version pinned, using configured default",
+				zap.String("version", nodeVersion),
+				zap.String("setting", def.setting),
+			)
 		}
+		ctx.L().Debug("using default node version", zap.String("path", p.path))
+
+	default:
+		ctx.L().Error(errNoVersionFound.Error())
+		return "", errNoVersionFound
 	}
 
 	return nodeVersion, nil
@@ -47,7 +47,7 @@ func TestCheckDependencies(t *testing.T) {
 
 		helper := newTestHelper(t)
 
-		_, err = checkDependencies(helper.ctx, fixtures)
+		_, err = checkDependencies(helper.ctx, project{path: fixtures, root: fixtures}, &nodeDefault{})
 		assert.Error(t, err)
 	})
 
@@ -59,12 +59,32 @@ func TestCheckDependencies(t *testing.T) {
 
 		helper := newTestHelper(t)
 
-		_, err = checkDependencies(helper.ctx, fixtures)
+		_, err = checkDependencies(helper.ctx, project{path: fixtures, root: fixtures}, &nodeDefault{})
 		assert.Error(t, err)
 		require.EqualError(t, err, errNoVersionFound.Error())
 	})
 
-	t.Run("Eslint not found as dependency", func(t *testing.T) {
+	t.Run("Uses the configured default node version", func(t *testing.T) {
+		data := []byte("{\"devDependencies\": {\"eslint\": \"v8.0\"}}")
+		err := os.WriteFile(pkgJsonPath, data, os.ModePerm)
+		require.NoError(t, err)
+
+		t.Cleanup(func() { _ = os.Remove(pkgJsonPath) })
+		t.Setenv(envDefaultNodeVersion, "latest LTS")
+
+		helper := newTestHelper(t)
+		def, err := loadNodeDefault(t.TempDir())
+		require.NoError(t, err)
+
+		for i := 0; i < 2; i++ {
+			version, err := checkDependencies(helper.ctx, project{path: fixtures, root: fixtures}, def)
+			require.NoError(t, err)
+			assert.Equal(t, "lts/*", version)
+			assert.True(t, def.announced)
+		}
+	})
+
+	t.Run("Does not require eslint as a dependency", func(t *testing.T) {
 		data := []byte("{\"engines\": {\"node\": \"v12.x\"}}")
 		err := os.WriteFile(pkgJsonPath, data, os.ModePerm)
 		require.NoError(t, err)
@@ -73,8 +93,9 @@ func TestCheckDependencies(t *testing.T) {
 
 		helper := newTestHelper(t)
 
-		_, err = checkDependencies(helper.ctx, fixtures)
-		require.EqualError(t, err, errNoEslintDep.Error())
+		version, err := checkDependencies(helper.ctx, project{path: fixtures, root: fixtures}, &nodeDefault{})
+		require.NoError(t, err)
+		assert.Equal(t, ver, version)
 	})
 
 	t.Run("Works as expected with eslint as dependency", func(t *testing.T) {
@@ -86,7 +107,7 @@ func TestCheckDependencies(t *testing.T) {
 
 		helper := newTestHelper(t)
 
-		version, err := checkDependencies(helper.ctx, fixtures)
+		version, err := checkDependencies(helper.ctx, project{path: fixtures, root: fixtures}, &nodeDefault{})
 		assert.NoError(t, err)
 		assert.Equal(t, version, ver)
 	})
@@ -100,7 +121,78 @@ func TestCheckDependencies(t *testing.T) {
 
 		helper := newTestHelper(t)
 
-		version, err := checkDependencies(helper.ctx, fixtures)
+		version, err := checkDependencies(helper.ctx, project{path: fixtures, root: fixtures}, &nodeDefault{})
 		assert.Equal(t, version, ver)
 	})
+
+	t.Run("Uses the node version of the workspace root", func(t *testing.T) {
+		root := t.TempDir()
+		member := filepath.Join(root, "packages", "app")
+		require.NoError(t, os.MkdirAll(member, os.ModePerm))
+		require.NoError(t, os.WriteFile(filepath.Join(root, pkgJson), []byte(`{"workspaces": ["packages/*"]}`), os.ModePerm))
+		require.NoError(t, os.WriteFile(filepath.Join(root, nvmrcFile), []byte("18\n"), os.ModePerm))
+		require.NoError(t, os.WriteFile(filepath.Join(member, pkgJson), []byte(`{"devDependencies": {"eslint": "v8.0"}}`), os.ModePerm))
+
+		helper := newTestHelper(t)
+
+		version, err := checkDependencies(helper.ctx, project{path: member, root: root}, &nodeDefault{})
+		require.NoError(t, err)
+		assert.Equal(t, "18", version)
+	})
+}
+
+func TestRelativePath(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "packages", "app", "src")
+	require.NoError(t, os.MkdirAll(nested, os.ModePerm))
+	file := filepath.Join(nested, "index.js")
+	require.NoError(t, os.WriteFile(file, nil, os.ModePerm))
+
+	t.Run("Nested packages", func(t *testing.T) {
+		rel, err := relativePath(root, file)
+		require.NoError(t, err)
+		assert.Equal(t, "packages/app/src/index.js", rel)
+	})
+
+	t.Run("Relative paths", func(t *testing.T) {
+		rel, err := relativePath(root, "packages/app/src/index.js")
+		require.NoError(t, err)
+		assert.Equal(t, "packages/app/src/index.js", rel)
+	})
+
+	t.Run("Symlinked root", func(t *testing.T) {
+		link := filepath.Join(t.TempDir(), "checkout")
+		require.NoError(t, os.Symlink(root, link))
+
+		rel, err := relativePath(link, file)
+		require.NoError(t, err)
+		assert.Equal(t, "packages/app/src/index.js", rel)
+
+		rel, err = relativePath(root, filepath.Join(link, "packages", "app", "src", "index.js"))
+		require.NoError(t, err)
+		assert.Equal(t, "packages/app/src/index.js", rel)
+	})
+
+	t.Run("Symlinked directories", func(t *testing.T) {
+		link := filepath.Join(root, "app")
+		require.NoError(t, os.Symlink(filepath.Join(root, "packages", "app"), link))
+
+		rel, err := relativePath(root, filepath.Join(link, "src", "index.js"))
+		require.NoError(t, err)
+		assert.Equal(t, "packages/app/src/index.js", rel)
+	})
+
+	t.Run("Missing files", func(t *testing.T) {
+		rel, err := relativePath(root, filepath.Join(root, "deleted.js"))
+		require.NoError(t, err)
+		assert.Equal(t, "deleted.js", rel)
+	})
+
+	t.Run("Rejects paths outside of the root", func(t *testing.T) {
+		outside := filepath.Join(t.TempDir(), "index.js")
+		require.NoError(t, os.WriteFile(outside, nil, os.ModePerm))
+
+		_, err := relativePath(root, outside)
+		assert.Error(t, err)
+	})
 }
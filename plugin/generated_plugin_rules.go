@@ -0,0 +1,528 @@
+// Code generated by generator/genrules. DO NOT EDIT.
+package plugin
+
+import "github.com/cocov-ci/go-plugin-kit/cocov"
+
+var pluginRules = map[string]map[string]cocov.IssueKind{
+	"@typescript-eslint": {
+		"adjacent-overload-signatures":            cocov.IssueKindConvention,
+		"array-type":                              cocov.IssueKindConvention,
+		"await-thenable":                          cocov.IssueKindBug,
+		"ban-ts-comment":                          cocov.IssueKindQuality,
+		"ban-tslint-comment":                      cocov.IssueKindConvention,
+		"ban-types":                               cocov.IssueKindConvention,
+		"block-spacing":                           cocov.IssueKindStyle,
+		"brace-style":                             cocov.IssueKindStyle,
+		"class-literal-property-style":            cocov.IssueKindConvention,
+		"class-methods-use-this":                  cocov.IssueKindConvention,
+		"comma-dangle":                            cocov.IssueKindStyle,
+		"comma-spacing":                           cocov.IssueKindStyle,
+		"consistent-generic-constructors":         cocov.IssueKindConvention,
+		"consistent-indexed-object-style":         cocov.IssueKindConvention,
+		"consistent-type-assertions":              cocov.IssueKindConvention,
+		"consistent-type-definitions":             cocov.IssueKindConvention,
+		"consistent-type-exports":                 cocov.IssueKindConvention,
+		"consistent-type-imports":                 cocov.IssueKindConvention,
+		"default-param-last":                      cocov.IssueKindConvention,
+		"dot-notation":                            cocov.IssueKindConvention,
+		"explicit-function-return-type":           cocov.IssueKindConvention,
+		"explicit-member-accessibility":           cocov.IssueKindConvention,
+		"explicit-module-boundary-types":          cocov.IssueKindConvention,
+		"func-call-spacing":                       cocov.IssueKindStyle,
+		"indent":                                  cocov.IssueKindStyle,
+		"init-declarations":                       cocov.IssueKindConvention,
+		"key-spacing":                             cocov.IssueKindStyle,
+		"keyword-spacing":                         cocov.IssueKindStyle,
+		"lines-around-comment":                    cocov.IssueKindStyle,
+		"lines-between-class-members":             cocov.IssueKindStyle,
+		"max-params":                              cocov.IssueKindComplexity,
+		"member-delimiter-style":                  cocov.IssueKindStyle,
+		"member-ordering":                         cocov.IssueKindStyle,
+		"method-signature-style":                  cocov.IssueKindConvention,
+		"naming-convention":                       cocov.IssueKindConvention,
+		"no-array-constructor":                    cocov.IssueKindConvention,
+		"no-array-delete":                         cocov.IssueKindBug,
+		"no-base-to-string":                       cocov.IssueKindBug,
+		"no-confusing-non-null-assertion":         cocov.IssueKindConvention,
+		"no-confusing-void-expression":            cocov.IssueKindBug,
+		"no-dupe-class-members":                   cocov.IssueKindBug,
+		"no-duplicate-enum-values":                cocov.IssueKindBug,
+		"no-duplicate-type-constituents":          cocov.IssueKindBug,
+		"no-dynamic-delete":                       cocov.IssueKindConvention,
+		"no-empty-function":                       cocov.IssueKindQuality,
+		"no-empty-interface":                      cocov.IssueKindConvention,
+		"no-explicit-any":                         cocov.IssueKindQuality,
+		"no-extra-non-null-assertion":             cocov.IssueKindBug,
+		"no-extra-parens":                         cocov.IssueKindStyle,
+		"no-extra-semi":                           cocov.IssueKindStyle,
+		"no-extraneous-class":                     cocov.IssueKindConvention,
+		"no-floating-promises":                    cocov.IssueKindBug,
+		"no-for-in-array":                         cocov.IssueKindBug,
+		"no-implied-eval":                         cocov.IssueKindSecurity,
+		"no-import-type-side-effects":             cocov.IssueKindConvention,
+		"no-inferrable-types":                     cocov.IssueKindConvention,
+		"no-invalid-this":                         cocov.IssueKindBug,
+		"no-invalid-void-type":                    cocov.IssueKindConvention,
+		"no-loop-func":                            cocov.IssueKindBug,
+		"no-loss-of-precision":                    cocov.IssueKindBug,
+		"no-magic-numbers":                        cocov.IssueKindConvention,
+		"no-misused-new":                          cocov.IssueKindBug,
+		"no-misused-promises":                     cocov.IssueKindBug,
+		"no-mixed-enums":                          cocov.IssueKindConvention,
+		"no-namespace":                            cocov.IssueKindConvention,
+		"no-non-null-asserted-nullish-coalescing": cocov.IssueKindBug,
+		"no-non-null-asserted-optional-chain":     cocov.IssueKindBug,
+		"no-non-null-assertion":                   cocov.IssueKindQuality,
+		"no-redeclare":                            cocov.IssueKindBug,
+		"no-redundant-type-constituents":          cocov.IssueKindBug,
+		"no-require-imports":                      cocov.IssueKindConvention,
+		"no-restricted-imports":                   cocov.IssueKindConvention,
+		"no-shadow":                               cocov.IssueKindBug,
+		"no-this-alias":                           cocov.IssueKindBug,
+		"no-unnecessary-boolean-literal-compare":  cocov.IssueKindConvention,
+		"no-unnecessary-condition":                cocov.IssueKindBug,
+		"no-unnecessary-qualifier":                cocov.IssueKindConvention,
+		"no-unnecessary-template-expression":      cocov.IssueKindConvention,
+		"no-unnecessary-type-arguments":           cocov.IssueKindConvention,
+		"no-unnecessary-type-assertion":           cocov.IssueKindQuality,
+		"no-unnecessary-type-constraint":          cocov.IssueKindConvention,
+		"no-unsafe-argument":                      cocov.IssueKindBug,
+		"no-unsafe-assignment":                    cocov.IssueKindBug,
+		"no-unsafe-call":                          cocov.IssueKindBug,
+		"no-unsafe-declaration-merging":           cocov.IssueKindBug,
+		"no-unsafe-enum-comparison":               cocov.IssueKindBug,
+		"no-unsafe-member-access":                 cocov.IssueKindBug,
+		"no-unsafe-return":                        cocov.IssueKindBug,
+		"no-unsafe-unary-minus":                   cocov.IssueKindBug,
+		"no-unused-expressions":                   cocov.IssueKindBug,
+		"no-unused-vars":                          cocov.IssueKindBug,
+		"no-use-before-define":                    cocov.IssueKindBug,
+		"no-useless-constructor":                  cocov.IssueKindQuality,
+		"no-useless-empty-export":                 cocov.IssueKindConvention,
+		"no-var-requires":                         cocov.IssueKindConvention,
+		"non-nullable-type-assertion-style":       cocov.IssueKindConvention,
+		"object-curly-spacing":                    cocov.IssueKindStyle,
+		"only-throw-error":                        cocov.IssueKindBug,
+		"padding-line-between-statements":         cocov.IssueKindStyle,
+		"parameter-properties":                    cocov.IssueKindConvention,
+		"prefer-as-const":                         cocov.IssueKindConvention,
+		"prefer-destructuring":                    cocov.IssueKindConvention,
+		"prefer-enum-initializers":                cocov.IssueKindConvention,
+		"prefer-for-of":                           cocov.IssueKindConvention,
+		"prefer-function-type":                    cocov.IssueKindConvention,
+		"prefer-includes":                         cocov.IssueKindConvention,
+		"prefer-literal-enum-member":              cocov.IssueKindConvention,
+		"prefer-namespace-keyword":                cocov.IssueKindConvention,
+		"prefer-nullish-coalescing":               cocov.IssueKindConvention,
+		"prefer-optional-chain":                   cocov.IssueKindConvention,
+		"prefer-promise-reject-errors":            cocov.IssueKindBug,
+		"prefer-readonly":                         cocov.IssueKindConvention,
+		"prefer-reduce-type-parameter":            cocov.IssueKindConvention,
+		"prefer-regexp-exec":                      cocov.IssueKindConvention,
+		"prefer-return-this-type":                 cocov.IssueKindConvention,
+		"prefer-string-starts-ends-with":          cocov.IssueKindConvention,
+		"prefer-ts-expect-error":                  cocov.IssueKindConvention,
+		"promise-function-async":                  cocov.IssueKindConvention,
+		"quotes":                                  cocov.IssueKindStyle,
+		"require-array-sort-compare":              cocov.IssueKindBug,
+		"require-await":                           cocov.IssueKindBug,
+		"restrict-plus-operands":                  cocov.IssueKindBug,
+		"restrict-template-expressions":           cocov.IssueKindBug,
+		"return-await":                            cocov.IssueKindBug,
+		"semi":                                    cocov.IssueKindStyle,
+		"sort-type-constituents":                  cocov.IssueKindStyle,
+		"space-before-blocks":                     cocov.IssueKindStyle,
+		"space-before-function-paren":             cocov.IssueKindStyle,
+		"space-infix-ops":                         cocov.IssueKindStyle,
+		"strict-boolean-expressions":              cocov.IssueKindBug,
+		"switch-exhaustiveness-check":             cocov.IssueKindBug,
+		"triple-slash-reference":                  cocov.IssueKindConvention,
+		"type-annotation-spacing":                 cocov.IssueKindStyle,
+		"typedef":                                 cocov.IssueKindConvention,
+		"unbound-method":                          cocov.IssueKindBug,
+		"unified-signatures":                      cocov.IssueKindConvention,
+	},
+	"import": {
+		"consistent-type-specifier-style": cocov.IssueKindConvention,
+		"default":                         cocov.IssueKindBug,
+		"dynamic-import-chunkname":        cocov.IssueKindConvention,
+		"export":                          cocov.IssueKindBug,
+		"exports-last":                    cocov.IssueKindConvention,
+		"extensions":                      cocov.IssueKindConvention,
+		"first":                           cocov.IssueKindConvention,
+		"group-exports":                   cocov.IssueKindConvention,
+		"max-dependencies":                cocov.IssueKindComplexity,
+		"named":                           cocov.IssueKindBug,
+		"namespace":                       cocov.IssueKindBug,
+		"newline-after-import":            cocov.IssueKindStyle,
+		"no-absolute-path":                cocov.IssueKindConvention,
+		"no-amd":                          cocov.IssueKindConvention,
+		"no-anonymous-default-export":     cocov.IssueKindConvention,
+		"no-commonjs":                     cocov.IssueKindConvention,
+		"no-cycle":                        cocov.IssueKindComplexity,
+		"no-default-export":               cocov.IssueKindConvention,
+		"no-deprecated":                   cocov.IssueKindQuality,
+		"no-duplicates":                   cocov.IssueKindDuplication,
+		"no-dynamic-require":              cocov.IssueKindQuality,
+		"no-empty-named-blocks":           cocov.IssueKindConvention,
+		"no-extraneous-dependencies":      cocov.IssueKindBug,
+		"no-import-module-exports":        cocov.IssueKindBug,
+		"no-internal-modules":             cocov.IssueKindConvention,
+		"no-mutable-exports":              cocov.IssueKindBug,
+		"no-named-as-default":             cocov.IssueKindQuality,
+		"no-named-as-default-member":      cocov.IssueKindBug,
+		"no-named-default":                cocov.IssueKindConvention,
+		"no-named-export":                 cocov.IssueKindConvention,
+		"no-namespace":                    cocov.IssueKindConvention,
+		"no-nodejs-modules":               cocov.IssueKindConvention,
+		"no-relative-packages":            cocov.IssueKindConvention,
+		"no-relative-parent-imports":      cocov.IssueKindConvention,
+		"no-restricted-paths":             cocov.IssueKindConvention,
+		"no-self-import":                  cocov.IssueKindBug,
+		"no-unresolved":                   cocov.IssueKindBug,
+		"no-unused-modules":               cocov.IssueKindQuality,
+		"no-useless-path-segments":        cocov.IssueKindConvention,
+		"no-webpack-loader-syntax":        cocov.IssueKindBug,
+		"order":                           cocov.IssueKindStyle,
+		"prefer-default-export":           cocov.IssueKindConvention,
+		"unambiguous":                     cocov.IssueKindConvention,
+	},
+	"jsx-a11y": {
+		"alt-text":                                      cocov.IssueKindQuality,
+		"anchor-ambiguous-text":                         cocov.IssueKindQuality,
+		"anchor-has-content":                            cocov.IssueKindQuality,
+		"anchor-is-valid":                               cocov.IssueKindQuality,
+		"aria-activedescendant-has-tabindex":            cocov.IssueKindQuality,
+		"aria-props":                                    cocov.IssueKindBug,
+		"aria-proptypes":                                cocov.IssueKindBug,
+		"aria-role":                                     cocov.IssueKindBug,
+		"aria-unsupported-elements":                     cocov.IssueKindBug,
+		"autocomplete-valid":                            cocov.IssueKindQuality,
+		"click-events-have-key-events":                  cocov.IssueKindQuality,
+		"control-has-associated-label":                  cocov.IssueKindQuality,
+		"heading-has-content":                           cocov.IssueKindQuality,
+		"html-has-lang":                                 cocov.IssueKindQuality,
+		"iframe-has-title":                              cocov.IssueKindQuality,
+		"img-redundant-alt":                             cocov.IssueKindQuality,
+		"interactive-supports-focus":                    cocov.IssueKindQuality,
+		"label-has-associated-control":                  cocov.IssueKindQuality,
+		"label-has-for":                                 cocov.IssueKindQuality,
+		"lang":                                          cocov.IssueKindQuality,
+		"media-has-caption":                             cocov.IssueKindQuality,
+		"mouse-events-have-key-events":                  cocov.IssueKindQuality,
+		"no-access-key":                                 cocov.IssueKindQuality,
+		"no-aria-hidden-on-focusable":                   cocov.IssueKindQuality,
+		"no-autofocus":                                  cocov.IssueKindQuality,
+		"no-distracting-elements":                       cocov.IssueKindQuality,
+		"no-interactive-element-to-noninteractive-role": cocov.IssueKindQuality,
+		"no-noninteractive-element-interactions":        cocov.IssueKindQuality,
+		"no-noninteractive-element-to-interactive-role": cocov.IssueKindQuality,
+		"no-noninteractive-tabindex":                    cocov.IssueKindQuality,
+		"no-onchange":                                   cocov.IssueKindQuality,
+		"no-redundant-roles":                            cocov.IssueKindQuality,
+		"no-static-element-interactions":                cocov.IssueKindQuality,
+		"prefer-tag-over-role":                          cocov.IssueKindQuality,
+		"role-has-required-aria-props":                  cocov.IssueKindBug,
+		"role-supports-aria-props":                      cocov.IssueKindBug,
+		"scope":                                         cocov.IssueKindQuality,
+		"tabindex-no-positive":                          cocov.IssueKindQuality,
+	},
+	"n": {
+		"callback-return":                       cocov.IssueKindBug,
+		"exports-style":                         cocov.IssueKindConvention,
+		"file-extension-in-import":              cocov.IssueKindConvention,
+		"global-require":                        cocov.IssueKindConvention,
+		"handle-callback-err":                   cocov.IssueKindBug,
+		"hashbang":                              cocov.IssueKindConvention,
+		"no-callback-literal":                   cocov.IssueKindBug,
+		"no-deprecated-api":                     cocov.IssueKindQuality,
+		"no-exports-assign":                     cocov.IssueKindBug,
+		"no-extraneous-import":                  cocov.IssueKindBug,
+		"no-extraneous-require":                 cocov.IssueKindBug,
+		"no-missing-import":                     cocov.IssueKindBug,
+		"no-missing-require":                    cocov.IssueKindBug,
+		"no-mixed-requires":                     cocov.IssueKindConvention,
+		"no-new-require":                        cocov.IssueKindConvention,
+		"no-path-concat":                        cocov.IssueKindBug,
+		"no-process-env":                        cocov.IssueKindConvention,
+		"no-process-exit":                       cocov.IssueKindBug,
+		"no-restricted-import":                  cocov.IssueKindConvention,
+		"no-restricted-require":                 cocov.IssueKindConvention,
+		"no-sync":                               cocov.IssueKindPerformance,
+		"no-unpublished-bin":                    cocov.IssueKindBug,
+		"no-unpublished-import":                 cocov.IssueKindBug,
+		"no-unpublished-require":                cocov.IssueKindBug,
+		"no-unsupported-features/es-builtins":   cocov.IssueKindBug,
+		"no-unsupported-features/es-syntax":     cocov.IssueKindBug,
+		"no-unsupported-features/node-builtins": cocov.IssueKindBug,
+		"prefer-global/buffer":                  cocov.IssueKindConvention,
+		"prefer-global/console":                 cocov.IssueKindConvention,
+		"prefer-global/process":                 cocov.IssueKindConvention,
+		"prefer-global/text-decoder":            cocov.IssueKindConvention,
+		"prefer-global/text-encoder":            cocov.IssueKindConvention,
+		"prefer-global/url":                     cocov.IssueKindConvention,
+		"prefer-global/url-search-params":       cocov.IssueKindConvention,
+		"prefer-node-protocol":                  cocov.IssueKindConvention,
+		"prefer-promises/dns":                   cocov.IssueKindConvention,
+		"prefer-promises/fs":                    cocov.IssueKindConvention,
+		"process-exit-as-throw":                 cocov.IssueKindBug,
+		"shebang":                               cocov.IssueKindConvention,
+	},
+	"react": {
+		"boolean-prop-naming":                  cocov.IssueKindConvention,
+		"button-has-type":                      cocov.IssueKindBug,
+		"default-props-match-prop-types":       cocov.IssueKindConvention,
+		"destructuring-assignment":             cocov.IssueKindConvention,
+		"display-name":                         cocov.IssueKindConvention,
+		"forbid-component-props":               cocov.IssueKindConvention,
+		"forbid-dom-props":                     cocov.IssueKindConvention,
+		"forbid-elements":                      cocov.IssueKindConvention,
+		"forbid-foreign-prop-types":            cocov.IssueKindConvention,
+		"forbid-prop-types":                    cocov.IssueKindConvention,
+		"function-component-definition":        cocov.IssueKindConvention,
+		"hook-use-state":                       cocov.IssueKindConvention,
+		"jsx-boolean-value":                    cocov.IssueKindConvention,
+		"jsx-child-element-spacing":            cocov.IssueKindStyle,
+		"jsx-closing-bracket-location":         cocov.IssueKindStyle,
+		"jsx-closing-tag-location":             cocov.IssueKindStyle,
+		"jsx-curly-brace-presence":             cocov.IssueKindConvention,
+		"jsx-curly-newline":                    cocov.IssueKindStyle,
+		"jsx-curly-spacing":                    cocov.IssueKindStyle,
+		"jsx-equals-spacing":                   cocov.IssueKindStyle,
+		"jsx-filename-extension":               cocov.IssueKindConvention,
+		"jsx-first-prop-new-line":              cocov.IssueKindStyle,
+		"jsx-fragments":                        cocov.IssueKindConvention,
+		"jsx-handler-names":                    cocov.IssueKindConvention,
+		"jsx-indent":                           cocov.IssueKindStyle,
+		"jsx-indent-props":                     cocov.IssueKindStyle,
+		"jsx-key":                              cocov.IssueKindBug,
+		"jsx-max-depth":                        cocov.IssueKindComplexity,
+		"jsx-max-props-per-line":               cocov.IssueKindStyle,
+		"jsx-newline":                          cocov.IssueKindStyle,
+		"jsx-no-bind":                          cocov.IssueKindPerformance,
+		"jsx-no-comment-textnodes":             cocov.IssueKindBug,
+		"jsx-no-constructed-context-values":    cocov.IssueKindPerformance,
+		"jsx-no-duplicate-props":               cocov.IssueKindBug,
+		"jsx-no-leaked-render":                 cocov.IssueKindConvention,
+		"jsx-no-literals":                      cocov.IssueKindConvention,
+		"jsx-no-script-url":                    cocov.IssueKindSecurity,
+		"jsx-no-target-blank":                  cocov.IssueKindSecurity,
+		"jsx-no-undef":                         cocov.IssueKindBug,
+		"jsx-no-useless-fragment":              cocov.IssueKindConvention,
+		"jsx-one-expression-per-line":          cocov.IssueKindStyle,
+		"jsx-pascal-case":                      cocov.IssueKindConvention,
+		"jsx-props-no-multi-spaces":            cocov.IssueKindStyle,
+		"jsx-props-no-spread-multi":            cocov.IssueKindBug,
+		"jsx-props-no-spreading":               cocov.IssueKindConvention,
+		"jsx-sort-props":                       cocov.IssueKindStyle,
+		"jsx-tag-spacing":                      cocov.IssueKindStyle,
+		"jsx-wrap-multilines":                  cocov.IssueKindStyle,
+		"no-access-state-in-setstate":          cocov.IssueKindBug,
+		"no-adjacent-inline-elements":          cocov.IssueKindConvention,
+		"no-array-index-key":                   cocov.IssueKindBug,
+		"no-children-prop":                     cocov.IssueKindBug,
+		"no-danger":                            cocov.IssueKindSecurity,
+		"no-danger-with-children":              cocov.IssueKindBug,
+		"no-deprecated":                        cocov.IssueKindBug,
+		"no-did-mount-set-state":               cocov.IssueKindPerformance,
+		"no-did-update-set-state":              cocov.IssueKindPerformance,
+		"no-direct-mutation-state":             cocov.IssueKindBug,
+		"no-find-dom-node":                     cocov.IssueKindBug,
+		"no-is-mounted":                        cocov.IssueKindBug,
+		"no-multi-comp":                        cocov.IssueKindConvention,
+		"no-namespace":                         cocov.IssueKindBug,
+		"no-object-type-as-default-prop":       cocov.IssueKindPerformance,
+		"no-redundant-should-component-update": cocov.IssueKindBug,
+		"no-render-return-value":               cocov.IssueKindBug,
+		"no-set-state":                         cocov.IssueKindConvention,
+		"no-string-refs":                       cocov.IssueKindBug,
+		"no-this-in-sfc":                       cocov.IssueKindBug,
+		"no-typos":                             cocov.IssueKindBug,
+		"no-unescaped-entities":                cocov.IssueKindBug,
+		"no-unknown-property":                  cocov.IssueKindBug,
+		"no-unsafe":                            cocov.IssueKindBug,
+		"no-unstable-nested-components":        cocov.IssueKindPerformance,
+		"no-unused-class-component-methods":    cocov.IssueKindQuality,
+		"no-unused-prop-types":                 cocov.IssueKindQuality,
+		"no-unused-state":                      cocov.IssueKindQuality,
+		"no-will-update-set-state":             cocov.IssueKindBug,
+		"prefer-es6-class":                     cocov.IssueKindConvention,
+		"prefer-exact-props":                   cocov.IssueKindConvention,
+		"prefer-read-only-props":               cocov.IssueKindConvention,
+		"prefer-stateless-function":            cocov.IssueKindConvention,
+		"prop-types":                           cocov.IssueKindQuality,
+		"react-in-jsx-scope":                   cocov.IssueKindBug,
+		"require-default-props":                cocov.IssueKindConvention,
+		"require-optimization":                 cocov.IssueKindPerformance,
+		"require-render-return":                cocov.IssueKindBug,
+		"self-closing-comp":                    cocov.IssueKindStyle,
+		"sort-comp":                            cocov.IssueKindConvention,
+		"state-in-constructor":                 cocov.IssueKindConvention,
+		"static-property-placement":            cocov.IssueKindConvention,
+		"style-prop-object":                    cocov.IssueKindBug,
+		"void-dom-elements-no-children":        cocov.IssueKindBug,
+	},
+	"react-hooks": {
+		"exhaustive-deps": cocov.IssueKindBug,
+		"rules-of-hooks":  cocov.IssueKindBug,
+	},
+	"security": {
+		"detect-bidi-characters":                cocov.IssueKindSecurity,
+		"detect-buffer-noassert":                cocov.IssueKindSecurity,
+		"detect-child-process":                  cocov.IssueKindSecurity,
+		"detect-disable-mustache-escape":        cocov.IssueKindSecurity,
+		"detect-eval-with-expression":           cocov.IssueKindSecurity,
+		"detect-new-buffer":                     cocov.IssueKindSecurity,
+		"detect-no-csrf-before-method-override": cocov.IssueKindSecurity,
+		"detect-non-literal-fs-filename":        cocov.IssueKindSecurity,
+		"detect-non-literal-regexp":             cocov.IssueKindSecurity,
+		"detect-non-literal-require":            cocov.IssueKindSecurity,
+		"detect-object-injection":               cocov.IssueKindSecurity,
+		"detect-possible-timing-attacks":        cocov.IssueKindSecurity,
+		"detect-pseudoRandomBytes":              cocov.IssueKindSecurity,
+		"detect-unsafe-regex":                   cocov.IssueKindSecurity,
+	},
+	"sonarjs": {
+		"cognitive-complexity":         cocov.IssueKindComplexity,
+		"elseif-without-else":          cocov.IssueKindConvention,
+		"max-switch-cases":             cocov.IssueKindComplexity,
+		"no-all-duplicated-branches":   cocov.IssueKindBug,
+		"no-collapsible-if":            cocov.IssueKindConvention,
+		"no-collection-size-mischeck":  cocov.IssueKindBug,
+		"no-duplicate-string":          cocov.IssueKindDuplication,
+		"no-duplicated-branches":       cocov.IssueKindDuplication,
+		"no-element-overwrite":         cocov.IssueKindBug,
+		"no-empty-collection":          cocov.IssueKindBug,
+		"no-extra-arguments":           cocov.IssueKindBug,
+		"no-gratuitous-expressions":    cocov.IssueKindBug,
+		"no-identical-conditions":      cocov.IssueKindBug,
+		"no-identical-expressions":     cocov.IssueKindBug,
+		"no-identical-functions":       cocov.IssueKindDuplication,
+		"no-ignored-return":            cocov.IssueKindBug,
+		"no-inverted-boolean-check":    cocov.IssueKindConvention,
+		"no-nested-switch":             cocov.IssueKindComplexity,
+		"no-nested-template-literals":  cocov.IssueKindConvention,
+		"no-one-iteration-loop":        cocov.IssueKindBug,
+		"no-redundant-boolean":         cocov.IssueKindConvention,
+		"no-redundant-jump":            cocov.IssueKindQuality,
+		"no-same-line-conditional":     cocov.IssueKindBug,
+		"no-small-switch":              cocov.IssueKindConvention,
+		"no-unused-collection":         cocov.IssueKindBug,
+		"no-use-of-empty-return-value": cocov.IssueKindBug,
+		"no-useless-catch":             cocov.IssueKindQuality,
+		"non-existent-operator":        cocov.IssueKindBug,
+		"prefer-immediate-return":      cocov.IssueKindConvention,
+		"prefer-object-literal":        cocov.IssueKindConvention,
+		"prefer-single-boolean-return": cocov.IssueKindConvention,
+		"prefer-while":                 cocov.IssueKindConvention,
+	},
+	"unicorn": {
+		"better-regex":                            cocov.IssueKindConvention,
+		"catch-error-name":                        cocov.IssueKindConvention,
+		"consistent-destructuring":                cocov.IssueKindConvention,
+		"consistent-function-scoping":             cocov.IssueKindQuality,
+		"custom-error-definition":                 cocov.IssueKindConvention,
+		"empty-brace-spaces":                      cocov.IssueKindStyle,
+		"error-message":                           cocov.IssueKindQuality,
+		"escape-case":                             cocov.IssueKindStyle,
+		"expiring-todo-comments":                  cocov.IssueKindQuality,
+		"explicit-length-check":                   cocov.IssueKindConvention,
+		"filename-case":                           cocov.IssueKindConvention,
+		"import-style":                            cocov.IssueKindConvention,
+		"new-for-builtins":                        cocov.IssueKindConvention,
+		"no-abusive-eslint-disable":               cocov.IssueKindQuality,
+		"no-anonymous-default-export":             cocov.IssueKindConvention,
+		"no-array-callback-reference":             cocov.IssueKindBug,
+		"no-array-for-each":                       cocov.IssueKindConvention,
+		"no-array-method-this-argument":           cocov.IssueKindBug,
+		"no-array-reduce":                         cocov.IssueKindComplexity,
+		"no-await-expression-member":              cocov.IssueKindConvention,
+		"no-await-in-promise-methods":             cocov.IssueKindBug,
+		"no-console-spaces":                       cocov.IssueKindStyle,
+		"no-document-cookie":                      cocov.IssueKindSecurity,
+		"no-empty-file":                           cocov.IssueKindQuality,
+		"no-for-loop":                             cocov.IssueKindConvention,
+		"no-hex-escape":                           cocov.IssueKindStyle,
+		"no-instanceof-array":                     cocov.IssueKindBug,
+		"no-invalid-fetch-options":                cocov.IssueKindBug,
+		"no-invalid-remove-event-listener":        cocov.IssueKindBug,
+		"no-keyword-prefix":                       cocov.IssueKindConvention,
+		"no-length-as-slice-end":                  cocov.IssueKindBug,
+		"no-lonely-if":                            cocov.IssueKindConvention,
+		"no-negated-condition":                    cocov.IssueKindConvention,
+		"no-negation-in-equality-check":           cocov.IssueKindBug,
+		"no-nested-ternary":                       cocov.IssueKindComplexity,
+		"no-new-array":                            cocov.IssueKindBug,
+		"no-new-buffer":                           cocov.IssueKindSecurity,
+		"no-null":                                 cocov.IssueKindConvention,
+		"no-object-as-default-parameter":          cocov.IssueKindConvention,
+		"no-process-exit":                         cocov.IssueKindBug,
+		"no-single-promise-in-promise-methods":    cocov.IssueKindBug,
+		"no-static-only-class":                    cocov.IssueKindConvention,
+		"no-thenable":                             cocov.IssueKindBug,
+		"no-this-assignment":                      cocov.IssueKindConvention,
+		"no-typeof-undefined":                     cocov.IssueKindConvention,
+		"no-unnecessary-polyfills":                cocov.IssueKindConvention,
+		"no-unreadable-array-destructuring":       cocov.IssueKindBug,
+		"no-unsafe-regex":                         cocov.IssueKindSecurity,
+		"no-unused-properties":                    cocov.IssueKindQuality,
+		"no-useless-fallback-in-spread":           cocov.IssueKindConvention,
+		"no-useless-length-check":                 cocov.IssueKindPerformance,
+		"no-useless-promise-resolve-reject":       cocov.IssueKindQuality,
+		"no-useless-spread":                       cocov.IssueKindPerformance,
+		"no-useless-switch-case":                  cocov.IssueKindConvention,
+		"no-useless-undefined":                    cocov.IssueKindQuality,
+		"no-zero-fractions":                       cocov.IssueKindStyle,
+		"number-literal-case":                     cocov.IssueKindStyle,
+		"numeric-separators-style":                cocov.IssueKindStyle,
+		"prefer-add-event-listener":               cocov.IssueKindConvention,
+		"prefer-array-find":                       cocov.IssueKindPerformance,
+		"prefer-array-flat-map":                   cocov.IssueKindPerformance,
+		"prefer-array-index-of":                   cocov.IssueKindConvention,
+		"prefer-array-some":                       cocov.IssueKindPerformance,
+		"prefer-at":                               cocov.IssueKindConvention,
+		"prefer-default-parameters":               cocov.IssueKindConvention,
+		"prefer-dom-node-append":                  cocov.IssueKindConvention,
+		"prefer-dom-node-dataset":                 cocov.IssueKindConvention,
+		"prefer-dom-node-remove":                  cocov.IssueKindConvention,
+		"prefer-dom-node-text-content":            cocov.IssueKindConvention,
+		"prefer-includes":                         cocov.IssueKindConvention,
+		"prefer-logical-operator-over-ternary":    cocov.IssueKindConvention,
+		"prefer-math-trunc":                       cocov.IssueKindConvention,
+		"prefer-modern-dom-apis":                  cocov.IssueKindConvention,
+		"prefer-modern-math-apis":                 cocov.IssueKindConvention,
+		"prefer-module":                           cocov.IssueKindConvention,
+		"prefer-native-coercion-functions":        cocov.IssueKindConvention,
+		"prefer-negative-index":                   cocov.IssueKindConvention,
+		"prefer-node-protocol":                    cocov.IssueKindConvention,
+		"prefer-number-properties":                cocov.IssueKindConvention,
+		"prefer-object-from-entries":              cocov.IssueKindConvention,
+		"prefer-optional-catch-binding":           cocov.IssueKindConvention,
+		"prefer-prototype-methods":                cocov.IssueKindConvention,
+		"prefer-query-selector":                   cocov.IssueKindConvention,
+		"prefer-reflect-apply":                    cocov.IssueKindConvention,
+		"prefer-regexp-test":                      cocov.IssueKindPerformance,
+		"prefer-set-has":                          cocov.IssueKindPerformance,
+		"prefer-spread":                           cocov.IssueKindConvention,
+		"prefer-string-replace-all":               cocov.IssueKindConvention,
+		"prefer-string-slice":                     cocov.IssueKindConvention,
+		"prefer-string-starts-ends-with":          cocov.IssueKindConvention,
+		"prefer-string-trim-start-end":            cocov.IssueKindConvention,
+		"prefer-switch":                           cocov.IssueKindConvention,
+		"prefer-ternary":                          cocov.IssueKindConvention,
+		"prefer-top-level-await":                  cocov.IssueKindConvention,
+		"prefer-type-error":                       cocov.IssueKindConvention,
+		"prevent-abbreviations":                   cocov.IssueKindConvention,
+		"relative-url-style":                      cocov.IssueKindConvention,
+		"require-array-join-separator":            cocov.IssueKindBug,
+		"require-number-to-fixed-digits-argument": cocov.IssueKindBug,
+		"switch-case-braces":                      cocov.IssueKindStyle,
+		"template-indent":                         cocov.IssueKindStyle,
+		"text-encoding-identifier-case":           cocov.IssueKindStyle,
+		"throw-new-error":                         cocov.IssueKindConvention,
+	},
+}
+var pluginAliases = map[string]string{
+	"import-x": "import",
+	"node":     "n",
+}
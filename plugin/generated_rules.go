@@ -3,272 +3,297 @@ package plugin
 
 import "github.com/cocov-ci/go-plugin-kit/cocov"
 
-var rules = map[string]cocov.IssueKind{
-	"array-bracket-newline":            cocov.IssueKindStyle,
-	"array-bracket-spacing":            cocov.IssueKindStyle,
-	"array-element-newline":            cocov.IssueKindStyle,
-	"arrow-parens":                     cocov.IssueKindStyle,
-	"arrow-spacing":                    cocov.IssueKindStyle,
-	"block-spacing":                    cocov.IssueKindStyle,
-	"brace-style":                      cocov.IssueKindStyle,
-	"comma-dangle":                     cocov.IssueKindStyle,
-	"comma-spacing":                    cocov.IssueKindStyle,
-	"comma-style":                      cocov.IssueKindStyle,
-	"computed-property-spacing":        cocov.IssueKindStyle,
-	"dot-location":                     cocov.IssueKindStyle,
-	"eol-last":                         cocov.IssueKindStyle,
-	"func-call-spacing":                cocov.IssueKindStyle,
-	"function-call-argument-newline":   cocov.IssueKindStyle,
-	"function-paren-newline":           cocov.IssueKindStyle,
-	"generator-star-spacing":           cocov.IssueKindStyle,
-	"implicit-arrow-linebreak":         cocov.IssueKindStyle,
-	"indent":                           cocov.IssueKindStyle,
-	"jsx-quotes":                       cocov.IssueKindStyle,
-	"key-spacing":                      cocov.IssueKindStyle,
-	"keyword-spacing":                  cocov.IssueKindStyle,
-	"line-comment-position":            cocov.IssueKindStyle,
-	"linebreak-style":                  cocov.IssueKindStyle,
-	"lines-around-comment":             cocov.IssueKindStyle,
-	"lines-between-class-members":      cocov.IssueKindStyle,
-	"max-len":                          cocov.IssueKindStyle,
-	"max-statements-per-line":          cocov.IssueKindStyle,
-	"multiline-ternary":                cocov.IssueKindStyle,
-	"new-parens":                       cocov.IssueKindStyle,
-	"newline-per-chained-call":         cocov.IssueKindStyle,
-	"no-extra-parens":                  cocov.IssueKindStyle,
-	"no-mixed-spaces-and-tabs":         cocov.IssueKindStyle,
-	"no-multi-spaces":                  cocov.IssueKindStyle,
-	"no-multiple-empty-lines":          cocov.IssueKindStyle,
-	"no-tabs":                          cocov.IssueKindStyle,
-	"no-trailing-spaces":               cocov.IssueKindStyle,
-	"no-whitespace-before-property":    cocov.IssueKindStyle,
-	"nonblock-statement-body-position": cocov.IssueKindStyle,
-	"object-curly-newline":             cocov.IssueKindStyle,
-	"object-curly-spacing":             cocov.IssueKindStyle,
-	"object-property-newline":          cocov.IssueKindStyle,
-	"operator-linebreak":               cocov.IssueKindStyle,
-	"padded-blocks":                    cocov.IssueKindStyle,
-	"padding-line-between-statements":  cocov.IssueKindStyle,
-	"quotes":                           cocov.IssueKindStyle,
-	"rest-spread-spacing":              cocov.IssueKindStyle,
-	"semi":                             cocov.IssueKindStyle,
-	"semi-spacing":                     cocov.IssueKindStyle,
-	"semi-style":                       cocov.IssueKindStyle,
-	"space-before-blocks":              cocov.IssueKindStyle,
-	"space-before-function-paren":      cocov.IssueKindStyle,
-	"space-in-parens":                  cocov.IssueKindStyle,
-	"space-infix-ops":                  cocov.IssueKindStyle,
-	"space-unary-ops":                  cocov.IssueKindStyle,
-	"switch-colon-spacing":             cocov.IssueKindStyle,
-	"template-curly-spacing":           cocov.IssueKindStyle,
-	"template-tag-spacing":             cocov.IssueKindStyle,
-	"unicode-bom":                      cocov.IssueKindStyle,
-	"wrap-iife":                        cocov.IssueKindStyle,
-	"wrap-regex":                       cocov.IssueKindStyle,
-	"yield-star-spacing":               cocov.IssueKindStyle,
-	"array-callback-return":            cocov.IssueKindBug,
-	"constructor-super":                cocov.IssueKindBug,
-	"for-direction":                    cocov.IssueKindBug,
-	"getter-return":                    cocov.IssueKindBug,
-	"no-async-promise-executor":        cocov.IssueKindBug,
-	"no-await-in-loop":                 cocov.IssueKindBug,
-	"no-class-assign":                  cocov.IssueKindBug,
-	"no-compare-neg-zero":              cocov.IssueKindBug,
-	"no-cond-assign":                   cocov.IssueKindBug,
-	"no-const-assign":                  cocov.IssueKindBug,
-	"no-constant-binary-expression":    cocov.IssueKindBug,
-	"no-constant-condition":            cocov.IssueKindBug,
-	"no-constructor-return":            cocov.IssueKindBug,
-	"no-control-regex":                 cocov.IssueKindBug,
-	"no-debugger":                      cocov.IssueKindBug,
-	"no-dupe-args":                     cocov.IssueKindBug,
-	"no-dupe-class-members":            cocov.IssueKindBug,
-	"no-dupe-else-if":                  cocov.IssueKindBug,
-	"no-dupe-keys":                     cocov.IssueKindBug,
-	"no-duplicate-case":                cocov.IssueKindBug,
-	"no-duplicate-imports":             cocov.IssueKindBug,
-	"no-empty-character-class":         cocov.IssueKindBug,
-	"no-empty-pattern":                 cocov.IssueKindBug,
-	"no-ex-assign":                     cocov.IssueKindBug,
-	"no-fallthrough":                   cocov.IssueKindBug,
-	"no-func-assign":                   cocov.IssueKindBug,
-	"no-import-assign":                 cocov.IssueKindBug,
-	"no-inner-declarations":            cocov.IssueKindBug,
-	"no-invalid-regexp":                cocov.IssueKindBug,
-	"no-irregular-whitespace":          cocov.IssueKindBug,
-	"no-loss-of-precision":             cocov.IssueKindBug,
-	"no-misleading-character-class":    cocov.IssueKindBug,
-	"no-new-native-nonconstructor":     cocov.IssueKindBug,
-	"no-new-symbol":                    cocov.IssueKindBug,
-	"no-obj-calls":                     cocov.IssueKindBug,
-	"no-promise-executor-return":       cocov.IssueKindBug,
-	"no-prototype-builtins":            cocov.IssueKindBug,
-	"no-self-assign":                   cocov.IssueKindBug,
-	"no-self-compare":                  cocov.IssueKindBug,
-	"no-setter-return":                 cocov.IssueKindBug,
-	"no-sparse-arrays":                 cocov.IssueKindBug,
-	"no-template-curly-in-string":      cocov.IssueKindBug,
-	"no-this-before-super":             cocov.IssueKindBug,
-	"no-undef":                         cocov.IssueKindBug,
-	"no-unexpected-multiline":          cocov.IssueKindBug,
-	"no-unmodified-loop-condition":     cocov.IssueKindBug,
-	"no-unreachable":                   cocov.IssueKindBug,
-	"no-unreachable-loop":              cocov.IssueKindBug,
-	"no-unsafe-finally":                cocov.IssueKindBug,
-	"no-unsafe-negation":               cocov.IssueKindBug,
-	"no-unsafe-optional-chaining":      cocov.IssueKindBug,
-	"no-unused-private-class-members":  cocov.IssueKindBug,
-	"no-unused-vars":                   cocov.IssueKindBug,
-	"no-use-before-define":             cocov.IssueKindBug,
-	"no-useless-backreference":         cocov.IssueKindBug,
-	"require-atomic-updates":           cocov.IssueKindBug,
-	"use-isnan":                        cocov.IssueKindBug,
-	"valid-typeof":                     cocov.IssueKindBug,
-	"accessor-pairs":                   cocov.IssueKindConvention,
-	"arrow-body-style":                 cocov.IssueKindConvention,
-	"block-scoped-var":                 cocov.IssueKindConvention,
-	"camelcase":                        cocov.IssueKindConvention,
-	"capitalized-comments":             cocov.IssueKindConvention,
-	"class-methods-use-this":           cocov.IssueKindConvention,
-	"complexity":                       cocov.IssueKindConvention,
-	"consistent-return":                cocov.IssueKindConvention,
-	"consistent-this":                  cocov.IssueKindConvention,
-	"curly":                            cocov.IssueKindConvention,
-	"default-case":                     cocov.IssueKindConvention,
-	"default-case-last":                cocov.IssueKindConvention,
-	"default-param-last":               cocov.IssueKindConvention,
-	"dot-notation":                     cocov.IssueKindConvention,
-	"eqeqeq":                           cocov.IssueKindConvention,
-	"func-name-matching":               cocov.IssueKindConvention,
-	"func-names":                       cocov.IssueKindConvention,
-	"func-style":                       cocov.IssueKindConvention,
-	"grouped-accessor-pairs":           cocov.IssueKindConvention,
-	"guard-for-in":                     cocov.IssueKindConvention,
-	"id-denylist":                      cocov.IssueKindConvention,
-	"id-length":                        cocov.IssueKindConvention,
-	"id-match":                         cocov.IssueKindConvention,
-	"init-declarations":                cocov.IssueKindConvention,
-	"logical-assignment-operators":     cocov.IssueKindConvention,
-	"max-classes-per-file":             cocov.IssueKindConvention,
-	"max-depth":                        cocov.IssueKindConvention,
-	"max-lines":                        cocov.IssueKindConvention,
-	"max-lines-per-function":           cocov.IssueKindConvention,
-	"max-nested-callbacks":             cocov.IssueKindConvention,
-	"max-params":                       cocov.IssueKindConvention,
-	"max-statements":                   cocov.IssueKindConvention,
-	"multiline-comment-style":          cocov.IssueKindConvention,
-	"new-cap":                          cocov.IssueKindConvention,
-	"no-alert":                         cocov.IssueKindConvention,
-	"no-array-constructor":             cocov.IssueKindConvention,
-	"no-bitwise":                       cocov.IssueKindConvention,
-	"no-caller":                        cocov.IssueKindConvention,
-	"no-case-declarations":             cocov.IssueKindConvention,
-	"no-confusing-arrow":               cocov.IssueKindConvention,
-	"no-console":                       cocov.IssueKindConvention,
-	"no-continue":                      cocov.IssueKindConvention,
-	"no-delete-var":                    cocov.IssueKindConvention,
-	"no-div-regex":                     cocov.IssueKindConvention,
-	"no-else-return":                   cocov.IssueKindConvention,
-	"no-empty":                         cocov.IssueKindConvention,
-	"no-empty-function":                cocov.IssueKindConvention,
-	"no-empty-static-block":            cocov.IssueKindConvention,
-	"no-eq-null":                       cocov.IssueKindConvention,
-	"no-eval":                          cocov.IssueKindConvention,
-	"no-extend-native":                 cocov.IssueKindConvention,
-	"no-extra-bind":                    cocov.IssueKindConvention,
-	"no-extra-boolean-cast":            cocov.IssueKindConvention,
-	"no-extra-label":                   cocov.IssueKindConvention,
-	"no-extra-semi":                    cocov.IssueKindConvention,
-	"no-floating-decimal":              cocov.IssueKindConvention,
-	"no-global-assign":                 cocov.IssueKindConvention,
-	"no-implicit-coercion":             cocov.IssueKindConvention,
-	"no-implicit-globals":              cocov.IssueKindConvention,
-	"no-implied-eval":                  cocov.IssueKindConvention,
-	"no-inline-comments":               cocov.IssueKindConvention,
-	"no-invalid-this":                  cocov.IssueKindConvention,
-	"no-iterator":                      cocov.IssueKindConvention,
-	"no-label-var":                     cocov.IssueKindConvention,
-	"no-labels":                        cocov.IssueKindConvention,
-	"no-lone-blocks":                   cocov.IssueKindConvention,
-	"no-lonely-if":                     cocov.IssueKindConvention,
-	"no-loop-func":                     cocov.IssueKindConvention,
-	"no-magic-numbers":                 cocov.IssueKindConvention,
-	"no-mixed-operators":               cocov.IssueKindConvention,
-	"no-multi-assign":                  cocov.IssueKindConvention,
-	"no-multi-str":                     cocov.IssueKindConvention,
-	"no-negated-condition":             cocov.IssueKindConvention,
-	"no-nested-ternary":                cocov.IssueKindConvention,
-	"no-new":                           cocov.IssueKindConvention,
-	"no-new-func":                      cocov.IssueKindConvention,
-	"no-new-object":                    cocov.IssueKindConvention,
-	"no-new-wrappers":                  cocov.IssueKindConvention,
-	"no-nonoctal-decimal-escape":       cocov.IssueKindConvention,
-	"no-octal":                         cocov.IssueKindConvention,
-	"no-octal-escape":                  cocov.IssueKindConvention,
-	"no-param-reassign":                cocov.IssueKindConvention,
-	"no-plusplus":                      cocov.IssueKindConvention,
-	"no-proto":                         cocov.IssueKindConvention,
-	"no-redeclare":                     cocov.IssueKindConvention,
-	"no-regex-spaces":                  cocov.IssueKindConvention,
-	"no-restricted-exports":            cocov.IssueKindConvention,
-	"no-restricted-globals":            cocov.IssueKindConvention,
-	"no-restricted-imports":            cocov.IssueKindConvention,
-	"no-restricted-properties":         cocov.IssueKindConvention,
-	"no-restricted-syntax":             cocov.IssueKindConvention,
-	"no-return-assign":                 cocov.IssueKindConvention,
-	"no-return-await":                  cocov.IssueKindConvention,
-	"no-script-url":                    cocov.IssueKindConvention,
-	"no-sequences":                     cocov.IssueKindConvention,
-	"no-shadow":                        cocov.IssueKindConvention,
-	"no-shadow-restricted-names":       cocov.IssueKindConvention,
-	"no-ternary":                       cocov.IssueKindConvention,
-	"no-throw-literal":                 cocov.IssueKindConvention,
-	"no-undef-init":                    cocov.IssueKindConvention,
-	"no-undefined":                     cocov.IssueKindConvention,
-	"no-underscore-dangle":             cocov.IssueKindConvention,
-	"no-unneeded-ternary":              cocov.IssueKindConvention,
-	"no-unused-expressions":            cocov.IssueKindConvention,
-	"no-unused-labels":                 cocov.IssueKindConvention,
-	"no-useless-call":                  cocov.IssueKindConvention,
-	"no-useless-catch":                 cocov.IssueKindConvention,
-	"no-useless-computed-key":          cocov.IssueKindConvention,
-	"no-useless-concat":                cocov.IssueKindConvention,
-	"no-useless-constructor":           cocov.IssueKindConvention,
-	"no-useless-escape":                cocov.IssueKindConvention,
-	"no-useless-rename":                cocov.IssueKindConvention,
-	"no-useless-return":                cocov.IssueKindConvention,
-	"no-var":                           cocov.IssueKindConvention,
-	"no-void":                          cocov.IssueKindConvention,
-	"no-warning-comments":              cocov.IssueKindConvention,
-	"no-with":                          cocov.IssueKindConvention,
-	"object-shorthand":                 cocov.IssueKindConvention,
-	"one-var":                          cocov.IssueKindConvention,
-	"one-var-declaration-per-line":     cocov.IssueKindConvention,
-	"operator-assignment":              cocov.IssueKindConvention,
-	"prefer-arrow-callback":            cocov.IssueKindConvention,
-	"prefer-const":                     cocov.IssueKindConvention,
-	"prefer-destructuring":             cocov.IssueKindConvention,
-	"prefer-exponentiation-operator":   cocov.IssueKindConvention,
-	"prefer-named-capture-group":       cocov.IssueKindConvention,
-	"prefer-numeric-literals":          cocov.IssueKindConvention,
-	"prefer-object-has-own":            cocov.IssueKindConvention,
-	"prefer-object-spread":             cocov.IssueKindConvention,
-	"prefer-promise-reject-errors":     cocov.IssueKindConvention,
-	"prefer-regex-literals":            cocov.IssueKindConvention,
-	"prefer-rest-params":               cocov.IssueKindConvention,
-	"prefer-spread":                    cocov.IssueKindConvention,
-	"prefer-template":                  cocov.IssueKindConvention,
-	"quote-props":                      cocov.IssueKindConvention,
-	"radix":                            cocov.IssueKindConvention,
-	"require-await":                    cocov.IssueKindConvention,
-	"require-unicode-regexp":           cocov.IssueKindConvention,
-	"require-yield":                    cocov.IssueKindConvention,
-	"sort-imports":                     cocov.IssueKindConvention,
-	"sort-keys":                        cocov.IssueKindConvention,
-	"sort-vars":                        cocov.IssueKindConvention,
-	"spaced-comment":                   cocov.IssueKindConvention,
-	"strict":                           cocov.IssueKindConvention,
-	"symbol-description":               cocov.IssueKindConvention,
-	"vars-on-top":                      cocov.IssueKindConvention,
-	"yoda":                             cocov.IssueKindConvention,
+// rules describes the core rules of eslint 8.57.0.
+var rules = map[string]coreRule{
+	"accessor-pairs":                   {kind: cocov.IssueKindConvention},
+	"array-bracket-newline":            {kind: cocov.IssueKindStyle, deprecated: true},
+	"array-bracket-spacing":            {kind: cocov.IssueKindStyle, deprecated: true},
+	"array-callback-return":            {kind: cocov.IssueKindBug},
+	"array-element-newline":            {kind: cocov.IssueKindStyle, deprecated: true},
+	"arrow-body-style":                 {kind: cocov.IssueKindConvention},
+	"arrow-parens":                     {kind: cocov.IssueKindStyle, deprecated: true},
+	"arrow-spacing":                    {kind: cocov.IssueKindStyle, deprecated: true},
+	"block-scoped-var":                 {kind: cocov.IssueKindConvention},
+	"block-spacing":                    {kind: cocov.IssueKindStyle, deprecated: true},
+	"brace-style":                      {kind: cocov.IssueKindStyle, deprecated: true},
+	"callback-return":                  {kind: cocov.IssueKindConvention, deprecated: true},
+	"camelcase":                        {kind: cocov.IssueKindConvention},
+	"capitalized-comments":             {kind: cocov.IssueKindConvention},
+	"class-methods-use-this":           {kind: cocov.IssueKindConvention},
+	"comma-dangle":                     {kind: cocov.IssueKindStyle, deprecated: true},
+	"comma-spacing":                    {kind: cocov.IssueKindStyle, deprecated: true},
+	"comma-style":                      {kind: cocov.IssueKindStyle, deprecated: true},
+	"complexity":                       {kind: cocov.IssueKindComplexity},
+	"computed-property-spacing":        {kind: cocov.IssueKindStyle, deprecated: true},
+	"consistent-return":                {kind: cocov.IssueKindConvention},
+	"consistent-this":                  {kind: cocov.IssueKindConvention},
+	"constructor-super":                {kind: cocov.IssueKindBug},
+	"curly":                            {kind: cocov.IssueKindConvention},
+	"default-case":                     {kind: cocov.IssueKindConvention},
+	"default-case-last":                {kind: cocov.IssueKindConvention},
+	"default-param-last":               {kind: cocov.IssueKindConvention},
+	"dot-location":                     {kind: cocov.IssueKindStyle, deprecated: true},
+	"dot-notation":                     {kind: cocov.IssueKindConvention},
+	"eol-last":                         {kind: cocov.IssueKindStyle, deprecated: true},
+	"eqeqeq":                           {kind: cocov.IssueKindConvention},
+	"for-direction":                    {kind: cocov.IssueKindBug},
+	"func-call-spacing":                {kind: cocov.IssueKindStyle, deprecated: true},
+	"func-name-matching":               {kind: cocov.IssueKindConvention},
+	"func-names":                       {kind: cocov.IssueKindConvention},
+	"func-style":                       {kind: cocov.IssueKindConvention},
+	"function-call-argument-newline":   {kind: cocov.IssueKindStyle, deprecated: true},
+	"function-paren-newline":           {kind: cocov.IssueKindStyle, deprecated: true},
+	"generator-star-spacing":           {kind: cocov.IssueKindStyle, deprecated: true},
+	"getter-return":                    {kind: cocov.IssueKindBug},
+	"global-require":                   {kind: cocov.IssueKindConvention, deprecated: true},
+	"grouped-accessor-pairs":           {kind: cocov.IssueKindConvention},
+	"guard-for-in":                     {kind: cocov.IssueKindConvention},
+	"handle-callback-err":              {kind: cocov.IssueKindConvention, deprecated: true},
+	"id-blacklist":                     {kind: cocov.IssueKindConvention, deprecated: true, replacedBy: []string{"id-denylist"}},
+	"id-denylist":                      {kind: cocov.IssueKindConvention},
+	"id-length":                        {kind: cocov.IssueKindConvention},
+	"id-match":                         {kind: cocov.IssueKindConvention},
+	"implicit-arrow-linebreak":         {kind: cocov.IssueKindStyle, deprecated: true},
+	"indent":                           {kind: cocov.IssueKindStyle, deprecated: true},
+	"indent-legacy":                    {kind: cocov.IssueKindStyle, deprecated: true, replacedBy: []string{"indent"}},
+	"init-declarations":                {kind: cocov.IssueKindConvention},
+	"jsx-quotes":                       {kind: cocov.IssueKindStyle, deprecated: true},
+	"key-spacing":                      {kind: cocov.IssueKindStyle, deprecated: true},
+	"keyword-spacing":                  {kind: cocov.IssueKindStyle, deprecated: true},
+	"line-comment-position":            {kind: cocov.IssueKindStyle},
+	"linebreak-style":                  {kind: cocov.IssueKindStyle, deprecated: true},
+	"lines-around-comment":             {kind: cocov.IssueKindStyle, deprecated: true},
+	"lines-around-directive":           {kind: cocov.IssueKindStyle, deprecated: true, replacedBy: []string{"padding-line-between-statements"}},
+	"lines-between-class-members":      {kind: cocov.IssueKindStyle, deprecated: true},
+	"logical-assignment-operators":     {kind: cocov.IssueKindConvention},
+	"max-classes-per-file":             {kind: cocov.IssueKindComplexity},
+	"max-depth":                        {kind: cocov.IssueKindComplexity},
+	"max-len":                          {kind: cocov.IssueKindStyle, deprecated: true},
+	"max-lines":                        {kind: cocov.IssueKindComplexity},
+	"max-lines-per-function":           {kind: cocov.IssueKindComplexity},
+	"max-nested-callbacks":             {kind: cocov.IssueKindComplexity},
+	"max-params":                       {kind: cocov.IssueKindComplexity},
+	"max-statements":                   {kind: cocov.IssueKindComplexity},
+	"max-statements-per-line":          {kind: cocov.IssueKindStyle, deprecated: true},
+	"multiline-comment-style":          {kind: cocov.IssueKindConvention},
+	"multiline-ternary":                {kind: cocov.IssueKindStyle, deprecated: true},
+	"new-cap":                          {kind: cocov.IssueKindConvention},
+	"new-parens":                       {kind: cocov.IssueKindStyle, deprecated: true},
+	"newline-after-var":                {kind: cocov.IssueKindStyle, deprecated: true, replacedBy: []string{"padding-line-between-statements"}},
+	"newline-before-return":            {kind: cocov.IssueKindStyle, deprecated: true, replacedBy: []string{"padding-line-between-statements"}},
+	"newline-per-chained-call":         {kind: cocov.IssueKindStyle, deprecated: true},
+	"no-alert":                         {kind: cocov.IssueKindConvention},
+	"no-array-constructor":             {kind: cocov.IssueKindConvention},
+	"no-async-promise-executor":        {kind: cocov.IssueKindBug},
+	"no-await-in-loop":                 {kind: cocov.IssueKindPerformance},
+	"no-bitwise":                       {kind: cocov.IssueKindConvention},
+	"no-buffer-constructor":            {kind: cocov.IssueKindBug, deprecated: true},
+	"no-caller":                        {kind: cocov.IssueKindConvention},
+	"no-case-declarations":             {kind: cocov.IssueKindQuality},
+	"no-catch-shadow":                  {kind: cocov.IssueKindConvention, deprecated: true, replacedBy: []string{"no-shadow"}},
+	"no-class-assign":                  {kind: cocov.IssueKindBug},
+	"no-compare-neg-zero":              {kind: cocov.IssueKindBug},
+	"no-cond-assign":                   {kind: cocov.IssueKindBug},
+	"no-confusing-arrow":               {kind: cocov.IssueKindConvention, deprecated: true},
+	"no-console":                       {kind: cocov.IssueKindConvention},
+	"no-const-assign":                  {kind: cocov.IssueKindBug},
+	"no-constant-binary-expression":    {kind: cocov.IssueKindBug},
+	"no-constant-condition":            {kind: cocov.IssueKindBug},
+	"no-constructor-return":            {kind: cocov.IssueKindBug},
+	"no-continue":                      {kind: cocov.IssueKindConvention},
+	"no-control-regex":                 {kind: cocov.IssueKindBug},
+	"no-debugger":                      {kind: cocov.IssueKindBug},
+	"no-delete-var":                    {kind: cocov.IssueKindQuality},
+	"no-div-regex":                     {kind: cocov.IssueKindConvention},
+	"no-dupe-args":                     {kind: cocov.IssueKindBug},
+	"no-dupe-class-members":            {kind: cocov.IssueKindBug},
+	"no-dupe-else-if":                  {kind: cocov.IssueKindBug},
+	"no-dupe-keys":                     {kind: cocov.IssueKindBug},
+	"no-duplicate-case":                {kind: cocov.IssueKindBug},
+	"no-duplicate-imports":             {kind: cocov.IssueKindDuplication},
+	"no-else-return":                   {kind: cocov.IssueKindConvention},
+	"no-empty":                         {kind: cocov.IssueKindQuality},
+	"no-empty-character-class":         {kind: cocov.IssueKindBug},
+	"no-empty-function":                {kind: cocov.IssueKindConvention},
+	"no-empty-pattern":                 {kind: cocov.IssueKindBug},
+	"no-empty-static-block":            {kind: cocov.IssueKindConvention},
+	"no-eq-null":                       {kind: cocov.IssueKindConvention},
+	"no-eval":                          {kind: cocov.IssueKindSecurity},
+	"no-ex-assign":                     {kind: cocov.IssueKindBug},
+	"no-extend-native":                 {kind: cocov.IssueKindConvention},
+	"no-extra-bind":                    {kind: cocov.IssueKindConvention},
+	"no-extra-boolean-cast":            {kind: cocov.IssueKindQuality},
+	"no-extra-label":                   {kind: cocov.IssueKindConvention},
+	"no-extra-parens":                  {kind: cocov.IssueKindStyle, deprecated: true},
+	"no-extra-semi":                    {kind: cocov.IssueKindQuality, deprecated: true},
+	"no-fallthrough":                   {kind: cocov.IssueKindBug},
+	"no-floating-decimal":              {kind: cocov.IssueKindConvention, deprecated: true},
+	"no-func-assign":                   {kind: cocov.IssueKindBug},
+	"no-global-assign":                 {kind: cocov.IssueKindQuality},
+	"no-implicit-coercion":             {kind: cocov.IssueKindConvention},
+	"no-implicit-globals":              {kind: cocov.IssueKindConvention},
+	"no-implied-eval":                  {kind: cocov.IssueKindSecurity},
+	"no-import-assign":                 {kind: cocov.IssueKindBug},
+	"no-inline-comments":               {kind: cocov.IssueKindConvention},
+	"no-inner-declarations":            {kind: cocov.IssueKindBug},
+	"no-invalid-regexp":                {kind: cocov.IssueKindBug},
+	"no-invalid-this":                  {kind: cocov.IssueKindConvention},
+	"no-irregular-whitespace":          {kind: cocov.IssueKindBug},
+	"no-iterator":                      {kind: cocov.IssueKindConvention},
+	"no-label-var":                     {kind: cocov.IssueKindConvention},
+	"no-labels":                        {kind: cocov.IssueKindConvention},
+	"no-lone-blocks":                   {kind: cocov.IssueKindConvention},
+	"no-lonely-if":                     {kind: cocov.IssueKindConvention},
+	"no-loop-func":                     {kind: cocov.IssueKindConvention},
+	"no-loss-of-precision":             {kind: cocov.IssueKindBug},
+	"no-magic-numbers":                 {kind: cocov.IssueKindConvention},
+	"no-misleading-character-class":    {kind: cocov.IssueKindBug},
+	"no-mixed-operators":               {kind: cocov.IssueKindConvention, deprecated: true},
+	"no-mixed-requires":                {kind: cocov.IssueKindConvention, deprecated: true},
+	"no-mixed-spaces-and-tabs":         {kind: cocov.IssueKindStyle, deprecated: true},
+	"no-multi-assign":                  {kind: cocov.IssueKindConvention},
+	"no-multi-spaces":                  {kind: cocov.IssueKindStyle, deprecated: true},
+	"no-multi-str":                     {kind: cocov.IssueKindConvention},
+	"no-multiple-empty-lines":          {kind: cocov.IssueKindStyle, deprecated: true},
+	"no-native-reassign":               {kind: cocov.IssueKindConvention, deprecated: true, replacedBy: []string{"no-global-assign"}},
+	"no-negated-condition":             {kind: cocov.IssueKindConvention},
+	"no-negated-in-lhs":                {kind: cocov.IssueKindBug, deprecated: true, replacedBy: []string{"no-unsafe-negation"}},
+	"no-nested-ternary":                {kind: cocov.IssueKindComplexity},
+	"no-new":                           {kind: cocov.IssueKindConvention},
+	"no-new-func":                      {kind: cocov.IssueKindSecurity},
+	"no-new-native-nonconstructor":     {kind: cocov.IssueKindBug},
+	"no-new-object":                    {kind: cocov.IssueKindConvention, deprecated: true, replacedBy: []string{"no-object-constructor"}},
+	"no-new-require":                   {kind: cocov.IssueKindConvention, deprecated: true},
+	"no-new-symbol":                    {kind: cocov.IssueKindBug},
+	"no-new-wrappers":                  {kind: cocov.IssueKindConvention},
+	"no-nonoctal-decimal-escape":       {kind: cocov.IssueKindQuality},
+	"no-obj-calls":                     {kind: cocov.IssueKindBug},
+	"no-object-constructor":            {kind: cocov.IssueKindConvention},
+	"no-octal":                         {kind: cocov.IssueKindQuality},
+	"no-octal-escape":                  {kind: cocov.IssueKindConvention},
+	"no-param-reassign":                {kind: cocov.IssueKindConvention},
+	"no-path-concat":                   {kind: cocov.IssueKindConvention, deprecated: true},
+	"no-plusplus":                      {kind: cocov.IssueKindConvention},
+	"no-process-env":                   {kind: cocov.IssueKindConvention, deprecated: true},
+	"no-process-exit":                  {kind: cocov.IssueKindConvention, deprecated: true},
+	"no-promise-executor-return":       {kind: cocov.IssueKindBug},
+	"no-proto":                         {kind: cocov.IssueKindConvention},
+	"no-prototype-builtins":            {kind: cocov.IssueKindBug},
+	"no-redeclare":                     {kind: cocov.IssueKindQuality},
+	"no-regex-spaces":                  {kind: cocov.IssueKindQuality},
+	"no-restricted-exports":            {kind: cocov.IssueKindConvention},
+	"no-restricted-globals":            {kind: cocov.IssueKindConvention},
+	"no-restricted-imports":            {kind: cocov.IssueKindConvention},
+	"no-restricted-modules":            {kind: cocov.IssueKindConvention, deprecated: true},
+	"no-restricted-properties":         {kind: cocov.IssueKindConvention},
+	"no-restricted-syntax":             {kind: cocov.IssueKindConvention},
+	"no-return-assign":                 {kind: cocov.IssueKindConvention},
+	"no-return-await":                  {kind: cocov.IssueKindConvention, deprecated: true},
+	"no-script-url":                    {kind: cocov.IssueKindSecurity},
+	"no-self-assign":                   {kind: cocov.IssueKindBug},
+	"no-self-compare":                  {kind: cocov.IssueKindBug},
+	"no-sequences":                     {kind: cocov.IssueKindConvention},
+	"no-setter-return":                 {kind: cocov.IssueKindBug},
+	"no-shadow":                        {kind: cocov.IssueKindConvention},
+	"no-shadow-restricted-names":       {kind: cocov.IssueKindQuality},
+	"no-spaced-func":                   {kind: cocov.IssueKindStyle, deprecated: true, replacedBy: []string{"func-call-spacing"}},
+	"no-sparse-arrays":                 {kind: cocov.IssueKindBug},
+	"no-sync":                          {kind: cocov.IssueKindConvention, deprecated: true},
+	"no-tabs":                          {kind: cocov.IssueKindStyle, deprecated: true},
+	"no-template-curly-in-string":      {kind: cocov.IssueKindBug},
+	"no-ternary":                       {kind: cocov.IssueKindConvention},
+	"no-this-before-super":             {kind: cocov.IssueKindBug},
+	"no-throw-literal":                 {kind: cocov.IssueKindConvention},
+	"no-trailing-spaces":               {kind: cocov.IssueKindStyle, deprecated: true},
+	"no-undef":                         {kind: cocov.IssueKindBug},
+	"no-undef-init":                    {kind: cocov.IssueKindConvention},
+	"no-undefined":                     {kind: cocov.IssueKindConvention},
+	"no-underscore-dangle":             {kind: cocov.IssueKindConvention},
+	"no-unexpected-multiline":          {kind: cocov.IssueKindBug},
+	"no-unmodified-loop-condition":     {kind: cocov.IssueKindBug},
+	"no-unneeded-ternary":              {kind: cocov.IssueKindConvention},
+	"no-unreachable":                   {kind: cocov.IssueKindBug},
+	"no-unreachable-loop":              {kind: cocov.IssueKindBug},
+	"no-unsafe-finally":                {kind: cocov.IssueKindBug},
+	"no-unsafe-negation":               {kind: cocov.IssueKindBug},
+	"no-unsafe-optional-chaining":      {kind: cocov.IssueKindBug},
+	"no-unused-expressions":            {kind: cocov.IssueKindConvention},
+	"no-unused-labels":                 {kind: cocov.IssueKindQuality},
+	"no-unused-private-class-members":  {kind: cocov.IssueKindBug},
+	"no-unused-vars":                   {kind: cocov.IssueKindBug},
+	"no-use-before-define":             {kind: cocov.IssueKindBug},
+	"no-useless-backreference":         {kind: cocov.IssueKindBug},
+	"no-useless-call":                  {kind: cocov.IssueKindConvention},
+	"no-useless-catch":                 {kind: cocov.IssueKindQuality},
+	"no-useless-computed-key":          {kind: cocov.IssueKindConvention},
+	"no-useless-concat":                {kind: cocov.IssueKindConvention},
+	"no-useless-constructor":           {kind: cocov.IssueKindConvention},
+	"no-useless-escape":                {kind: cocov.IssueKindQuality},
+	"no-useless-rename":                {kind: cocov.IssueKindConvention},
+	"no-useless-return":                {kind: cocov.IssueKindConvention},
+	"no-var":                           {kind: cocov.IssueKindConvention},
+	"no-void":                          {kind: cocov.IssueKindConvention},
+	"no-warning-comments":              {kind: cocov.IssueKindConvention},
+	"no-whitespace-before-property":    {kind: cocov.IssueKindStyle, deprecated: true},
+	"no-with":                          {kind: cocov.IssueKindQuality},
+	"nonblock-statement-body-position": {kind: cocov.IssueKindStyle, deprecated: true},
+	"object-curly-newline":             {kind: cocov.IssueKindStyle, deprecated: true},
+	"object-curly-spacing":             {kind: cocov.IssueKindStyle, deprecated: true},
+	"object-property-newline":          {kind: cocov.IssueKindStyle, deprecated: true},
+	"object-shorthand":                 {kind: cocov.IssueKindConvention},
+	"one-var":                          {kind: cocov.IssueKindConvention},
+	"one-var-declaration-per-line":     {kind: cocov.IssueKindConvention, deprecated: true},
+	"operator-assignment":              {kind: cocov.IssueKindConvention},
+	"operator-linebreak":               {kind: cocov.IssueKindStyle, deprecated: true},
+	"padded-blocks":                    {kind: cocov.IssueKindStyle, deprecated: true},
+	"padding-line-between-statements":  {kind: cocov.IssueKindStyle, deprecated: true},
+	"prefer-arrow-callback":            {kind: cocov.IssueKindConvention},
+	"prefer-const":                     {kind: cocov.IssueKindConvention},
+	"prefer-destructuring":             {kind: cocov.IssueKindConvention},
+	"prefer-exponentiation-operator":   {kind: cocov.IssueKindConvention},
+	"prefer-named-capture-group":       {kind: cocov.IssueKindConvention},
+	"prefer-numeric-literals":          {kind: cocov.IssueKindConvention},
+	"prefer-object-has-own":            {kind: cocov.IssueKindConvention},
+	"prefer-object-spread":             {kind: cocov.IssueKindConvention},
+	"prefer-promise-reject-errors":     {kind: cocov.IssueKindConvention},
+	"prefer-reflect":                   {kind: cocov.IssueKindConvention, deprecated: true},
+	"prefer-regex-literals":            {kind: cocov.IssueKindConvention},
+	"prefer-rest-params":               {kind: cocov.IssueKindConvention},
+	"prefer-spread":                    {kind: cocov.IssueKindConvention},
+	"prefer-template":                  {kind: cocov.IssueKindConvention},
+	"quote-props":                      {kind: cocov.IssueKindConvention, deprecated: true},
+	"quotes":                           {kind: cocov.IssueKindStyle, deprecated: true},
+	"radix":                            {kind: cocov.IssueKindConvention},
+	"require-atomic-updates":           {kind: cocov.IssueKindBug},
+	"require-await":                    {kind: cocov.IssueKindConvention},
+	"require-jsdoc":                    {kind: cocov.IssueKindConvention, deprecated: true},
+	"require-unicode-regexp":           {kind: cocov.IssueKindConvention},
+	"require-yield":                    {kind: cocov.IssueKindQuality},
+	"rest-spread-spacing":              {kind: cocov.IssueKindStyle, deprecated: true},
+	"semi":                             {kind: cocov.IssueKindStyle, deprecated: true},
+	"semi-spacing":                     {kind: cocov.IssueKindStyle, deprecated: true},
+	"semi-style":                       {kind: cocov.IssueKindStyle, deprecated: true},
+	"sort-imports":                     {kind: cocov.IssueKindConvention},
+	"sort-keys":                        {kind: cocov.IssueKindConvention},
+	"sort-vars":                        {kind: cocov.IssueKindConvention},
+	"space-before-blocks":              {kind: cocov.IssueKindStyle, deprecated: true},
+	"space-before-function-paren":      {kind: cocov.IssueKindStyle, deprecated: true},
+	"space-in-parens":                  {kind: cocov.IssueKindStyle, deprecated: true},
+	"space-infix-ops":                  {kind: cocov.IssueKindStyle, deprecated: true},
+	"space-unary-ops":                  {kind: cocov.IssueKindStyle, deprecated: true},
+	"spaced-comment":                   {kind: cocov.IssueKindConvention, deprecated: true},
+	"strict":                           {kind: cocov.IssueKindConvention},
+	"switch-colon-spacing":             {kind: cocov.IssueKindStyle, deprecated: true},
+	"symbol-description":               {kind: cocov.IssueKindConvention},
+	"template-curly-spacing":           {kind: cocov.IssueKindStyle, deprecated: true},
+	"template-tag-spacing":             {kind: cocov.IssueKindStyle, deprecated: true},
+	"unicode-bom":                      {kind: cocov.IssueKindStyle},
+	"use-isnan":                        {kind: cocov.IssueKindBug},
+	"valid-jsdoc":                      {kind: cocov.IssueKindConvention, deprecated: true},
+	"valid-typeof":                     {kind: cocov.IssueKindBug},
+	"vars-on-top":                      {kind: cocov.IssueKindConvention},
+	"wrap-iife":                        {kind: cocov.IssueKindStyle, deprecated: true},
+	"wrap-regex":                       {kind: cocov.IssueKindStyle, deprecated: true},
+	"yield-star-spacing":               {kind: cocov.IssueKindStyle, deprecated: true},
+	"yoda":                             {kind: cocov.IssueKindConvention},
 }
@@ -45,3 +45,12 @@ func validOutput(t *testing.T) []byte {
 	require.NoError(t, err)
 	return data
 }
+
+func nodeIndexFixture(t *testing.T) []byte {
+	root := findRepositoryRoot(t)
+	dataPath := filepath.Join(root, "plugin", "fixtures", "node-index.json")
+
+	data, err := os.ReadFile(dataPath)
+	require.NoError(t, err)
+	return data
+}
@@ -0,0 +1,108 @@
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/cocov-ci/go-plugin-kit/cocov"
+	"go.uber.org/zap"
+)
+
+const (
+	indexCacheFile     = "index.json"
+	indexFetchedAtFile = "fetched-at"
+)
+
+// indexMemo holds indexes loaded during the current run, keyed by URL.
+var indexMemo = map[string]*versionIndex{}
+
+// loadNodeVersionIndex returns the version index available at url. Indexes
+// are kept in the tool cache under cacheRoot, and reused while they are not
+// older than the configured TTL.
+func loadNodeVersionIndex(ctx cocov.Context, url, cacheRoot string) (*versionIndex, error) {
+	if index, ok := indexMemo[url]; ok {
+		return index, nil
+	}
+
+	ttl, err := nodeIndexTTL()
+	if err != nil {
+		ctx.L().Error("invalid node index ttl", zap.Error(err))
+		return nil, err
+	}
+
+	key := indexCacheKey(url)
+	dir := filepath.Join(cacheRoot, key)
+	indexPath := filepath.Join(dir, indexCacheFile)
+
+	if ttl > 0 && ctx.LoadToolCache(key, dir) {
+		if age, ok := indexAge(dir); ok && age < ttl {
+			index, err := getNodeVersionIndex(ctx, indexPath)
+			if err == nil {
+				ctx.L().Info("using cached node version index",
+					zap.String("url", url),
+					zap.Duration("age", age),
+				)
+				indexMemo[url] = index
+				return index, nil
+			}
+		}
+	}
+
+	data, err := fetch(url)
+	if err != nil {
+		ctx.L().Error("failed to retrieve node version index",
+			zap.String("url", url),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	if err = os.MkdirAll(dir, os.ModePerm); err != nil {
+		ctx.L().Error("error creating directory", zap.String("path", dir), zap.Error(err))
+		return nil, err
+	}
+
+	if err = os.WriteFile(indexPath, data, os.ModePerm); err != nil {
+		ctx.L().Error("error writing node version index", zap.Error(err))
+		return nil, err
+	}
+
+	index, err := getNodeVersionIndex(ctx, indexPath)
+	if err != nil {
+		return nil, err
+	}
+
+	fetchedAt := []byte(time.Now().UTC().Format(time.RFC3339))
+	if err = os.WriteFile(filepath.Join(dir, indexFetchedAtFile), fetchedAt, os.ModePerm); err != nil {
+		ctx.L().Error("error writing node version index", zap.Error(err))
+		return nil, err
+	}
+
+	if ttl > 0 {
+		ctx.StoreToolCache(key, dir)
+	}
+
+	indexMemo[url] = index
+	return index, nil
+}
+
+// indexAge returns for how long the index cached at dir has been fetched.
+func indexAge(dir string) (time.Duration, bool) {
+	data, err := os.ReadFile(filepath.Join(dir, indexFetchedAtFile))
+	if err != nil {
+		return 0, false
+	}
+
+	fetchedAt, err := time.Parse(time.RFC3339, strings.TrimSpace(string(data)))
+	if err != nil {
+		return 0, false
+	}
+
+	return time.Since(fetchedAt), true
+}
+
+func indexCacheKey(url string) string {
+	return "node-index-" + cocov.SHA1([]byte(url))
+}
@@ -0,0 +1,96 @@
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/heyvito/httpie"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadNodeVersionIndex(t *testing.T) {
+	t.Cleanup(func() { indexMemo = map[string]*versionIndex{} })
+
+	writeCache := func(t *testing.T, root, url string, fetchedAt time.Time) {
+		dir := filepath.Join(root, indexCacheKey(url))
+		require.NoError(t, os.MkdirAll(dir, os.ModePerm))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, indexCacheFile), nodeIndexFixture(t), os.ModePerm))
+		ts := []byte(fetchedAt.UTC().Format(time.RFC3339))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, indexFetchedAtFile), ts, os.ModePerm))
+	}
+
+	t.Run("Fetches and caches the index", func(t *testing.T) {
+		indexMemo = map[string]*versionIndex{}
+		server := httpie.New(httpie.WithBytes("/index.json", "application/json", nodeIndexFixture(t)))
+		defer server.Stop()
+
+		url := server.URL + "/index.json"
+		root := t.TempDir()
+		key := indexCacheKey(url)
+
+		helper := newTestHelper(t)
+		helper.ctx.EXPECT().LoadToolCache(key, filepath.Join(root, key)).Return(false)
+		helper.ctx.EXPECT().StoreToolCache(key, filepath.Join(root, key))
+
+		index, err := loadNodeVersionIndex(helper.ctx, url, root)
+		require.NoError(t, err)
+		assert.NotEmpty(t, index.versions)
+		assert.FileExists(t, filepath.Join(root, key, indexCacheFile))
+
+		// Subsequent calls during the same run reuse the loaded index
+		again, err := loadNodeVersionIndex(helper.ctx, url, root)
+		require.NoError(t, err)
+		assert.Same(t, index, again)
+	})
+
+	t.Run("Uses a fresh cached index", func(t *testing.T) {
+		indexMemo = map[string]*versionIndex{}
+		url := "http://127.0.0.1:1/index.json"
+		root := t.TempDir()
+		key := indexCacheKey(url)
+		writeCache(t, root, url, time.Now().Add(-time.Hour))
+
+		helper := newTestHelper(t)
+		helper.ctx.EXPECT().LoadToolCache(key, filepath.Join(root, key)).Return(true)
+
+		index, err := loadNodeVersionIndex(helper.ctx, url, root)
+		require.NoError(t, err)
+		assert.NotEmpty(t, index.versions)
+	})
+
+	t.Run("Refreshes a stale cached index", func(t *testing.T) {
+		indexMemo = map[string]*versionIndex{}
+		server := httpie.New(httpie.WithBytes("/index.json", "application/json", []byte(`[{"version":"v20.0.0","lts":false}]`)))
+		defer server.Stop()
+
+		url := server.URL + "/index.json"
+		root := t.TempDir()
+		key := indexCacheKey(url)
+		writeCache(t, root, url, time.Now().Add(-48*time.Hour))
+
+		helper := newTestHelper(t)
+		helper.ctx.EXPECT().LoadToolCache(key, filepath.Join(root, key)).Return(true)
+		helper.ctx.EXPECT().StoreToolCache(key, filepath.Join(root, key))
+
+		index, err := loadNodeVersionIndex(helper.ctx, url, root)
+		require.NoError(t, err)
+		require.Len(t, index.versions, 1)
+		assert.Equal(t, "v20.0.0", index.versions[0].Version)
+	})
+
+	t.Run("Skips the tool cache when disabled", func(t *testing.T) {
+		indexMemo = map[string]*versionIndex{}
+		t.Setenv(envNodeIndexTTL, "0")
+		server := httpie.New(httpie.WithBytes("/index.json", "application/json", nodeIndexFixture(t)))
+		defer server.Stop()
+
+		helper := newTestHelper(t)
+
+		index, err := loadNodeVersionIndex(helper.ctx, server.URL+"/index.json", t.TempDir())
+		require.NoError(t, err)
+		assert.NotEmpty(t, index.versions)
+	})
+}
@@ -1,6 +1,7 @@
 package plugin
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
@@ -10,101 +11,201 @@ import (
 
 	"github.com/Masterminds/semver"
 	"github.com/cocov-ci/go-plugin-kit/cocov"
-	"github.com/levigross/grequests"
 	"go.uber.org/zap"
 )
 
 const (
-	nodeIndex = "https://nodejs.org/dist/index.json"
-	nodePath  = "/cocov/node"
-	pkgJson   = "package.json"
+	nodePath = "/cocov/node"
+	pkgJson  = "package.json"
 )
 
 type versionInfo struct {
 	Version string   `json:"version"`
 	Npm     string   `json:"npm"`
+	Date    string   `json:"date"`
+	LTS     ltsName  `json:"lts"`
 	Files   []string `json:"files"`
 }
 
+// ltsName holds the codename of the LTS line a release belongs to. The index
+// represents releases outside an LTS line as `false`, which is decoded as an
+// empty name.
+type ltsName string
+
+func (l *ltsName) UnmarshalJSON(data []byte) error {
+	if string(data) == "false" || string(data) == "null" {
+		*l = ""
+		return nil
+	}
+
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return err
+	}
+
+	*l = ltsName(name)
+	return nil
+}
+
 type versionIndex struct {
 	versions []versionInfo
 }
 
-func installNode(ctx cocov.Context, exec Exec, repoPath string) (string, error) {
-	rawPath := os.Getenv("PATH")
-	repoNodePath := filepath.Join(nodePath, repoPath)
-	binPath := path.Join(repoNodePath, "bin")
-	np := fmt.Sprintf("%s:%s", binPath, rawPath)
+// installedNodes holds the installation paths already prepared during the
+// current run.
+var installedNodes = map[string]bool{}
 
-	version, err := checkDependencies(ctx, repoPath)
+func installNode(ctx cocov.Context, exec Exec, p project, def *nodeDefault) (string, error) {
+	version, err := checkDependencies(ctx, p, def)
 	if err != nil {
 		return "", err
 	}
 
-	tck := toolCacheKey(version)
-	if ok := ctx.LoadToolCache(tck, repoNodePath); ok {
-		return np, nil
+	platform, err := detectPlatform()
+	if err != nil {
+		ctx.L().Error("failed to detect platform", zap.Error(err))
+		return "", err
 	}
 
-	consts, err := determineVersionConstraints(version)
+	policy, err := nodeVersionPolicy()
 	if err != nil {
+		ctx.L().Error("invalid node version policy", zap.Error(err))
 		return "", err
 	}
 
-	index, err := getNodeVersionIndex(ctx, nodeIndex)
+	index, err := loadNodeVersionIndex(ctx, nodeIndexURL(platform), nodePath)
 	if err != nil {
 		return "", err
 	}
 
-	availableVersion, err := findViableVersion(ctx, version, consts, index)
+	index = index.forPlatform(platform)
+
+	availableVersion, err := resolveNodeVersion(ctx, version, index, policy)
 	if err != nil {
 		return "", err
 	}
 
-	url := downloadURL(availableVersion)
-	zip, err := downloadNode(ctx, url, repoNodePath)
+	installPath := nodeInstallPath(availableVersion, platform)
+	binPath := path.Join(installPath, "bin")
+	np := fmt.Sprintf("%s:%s", binPath, os.Getenv("PATH"))
+
+	if installedNodes[installPath] {
+		ctx.L().Info("reusing node installation", zap.String("path", installPath))
+		return np, nil
+	}
+
+	tck := toolCacheKey(availableVersion, platform)
+	if ok := ctx.LoadToolCache(tck, installPath); ok {
+		installedNodes[installPath] = true
+		return np, nil
+	}
+
+	url := downloadURL(availableVersion, platform)
+	zip, err := downloadNode(ctx, url, installPath)
 	if err != nil {
 		return "", err
 	}
 
-	err = untar(ctx, exec, zip, repoNodePath)
+	sumsURL := checksumsURL(availableVersion, platform)
+	if err = verifyChecksum(ctx, sumsURL, archiveName(availableVersion, platform), zip); err != nil {
+		return "", err
+	}
+
+	err = untar(ctx, exec, zip, installPath)
 	if err != nil {
 		return "", err
 	}
 
-	ctx.StoreToolCache(tck, repoNodePath)
+	ctx.StoreToolCache(tck, installPath)
+	installedNodes[installPath] = true
 
 	return np, nil
 }
 
-func determineVersionConstraints(version string) (constraints, error) {
-	v, err := semver.NewConstraint(version)
-	if err == nil {
-		return constraints{v}, nil
+// rootNodePath returns the PATH used to install the dependencies of the
+// workspace root of p, given the PATH np prepared for p itself. Roots are
+// installed with the Node version they require, so that their dependencies
+// do not depend on the member processed first. Roots requiring no version,
+// in the absence of a default one, use the version of p.
+func rootNodePath(ctx cocov.Context, exec Exec, p project, np string, def *nodeDefault) (string, error) {
+	if p.root == p.path {
+		return np, nil
+	}
+
+	pkg, err := readPackageJson(ctx, p.root)
+	if err != nil {
+		return "", err
 	}
 
-	p := newParser()
+	version, _, err := findNodeVersion(p.root, pkg)
+	if err != nil {
+		ctx.L().Error("failed to read node version", zap.Error(err))
+		return "", err
+	}
+
+	if version == "" && def.version == "" {
+		ctx.L().Info("workspace root requires no node version, using the one of its member",
+			zap.String("root", p.root),
+			zap.String("member", p.path),
+		)
+		return np, nil
+	}
 
-	return p.parse(version)
+	return installNode(ctx, exec, project{path: p.root, root: p.root}, def)
+}
+
+// nodeInstallPath returns the directory in which a given Node version is
+// installed. Packages requiring the same version share its installation.
+func nodeInstallPath(version *semver.Version, platform string) string {
+	return filepath.Join(nodePath, toolCacheKey(version, platform))
+}
+
+func determineVersionConstraints(version string) (constraints, error) {
+	return newParser().parse(version)
 }
 
 func getNodeVersionIndex(ctx cocov.Context, url string) (*versionIndex, error) {
-	resp, err := grequests.Get(url, nil)
+	data, err := fetch(url)
 	if err != nil {
-		ctx.L().Error("failed to retrieve node version index", zap.Error(err))
+		ctx.L().Error("failed to retrieve node version index",
+			zap.String("url", url),
+			zap.Error(err),
+		)
 		return nil, err
 	}
 
 	ji := versionIndex{versions: []versionInfo{}}
-	if err = resp.JSON(&ji.versions); err != nil {
+	if err = json.Unmarshal(data, &ji.versions); err != nil {
 		ctx.L().Error("failed to decode response", zap.Error(err))
-		return nil, err
+		return nil, fmt.Errorf("failed to decode json index from %s: %w", url, err)
 	}
 
 	return &ji, nil
 }
 
-func findViableVersion(ctx cocov.Context, base string, c constraints, index *versionIndex) (*semver.Version, error) {
+// resolveNodeVersion picks a version from the index satisfying the provided
+// requirement, which may either be a semver range or an nvm alias. Ranges
+// are resolved according to the provided policy.
+func resolveNodeVersion(ctx cocov.Context, version string, index *versionIndex, policy versionPolicy) (*semver.Version, error) {
+	if isNodeAlias(version) {
+		return resolveNodeAlias(ctx, version, index)
+	}
+
+	consts, err := determineVersionConstraints(version)
+	if err != nil {
+		return nil, err
+	}
+
+	return findViableVersion(ctx, version, consts, index, policy)
+}
+
+// findViableVersion returns the version satisfying c chosen by policy. When
+// policy prefers LTS releases but no LTS release satisfies c, the newest
+// satisfying release is used instead.
+func findViableVersion(ctx cocov.Context, base string, c constraints, index *versionIndex, policy versionPolicy) (*semver.Version, error) {
+	var newest, newestLTS, oldest *semver.Version
+	var newestInfo, newestLTSInfo, oldestInfo versionInfo
+
 	for _, rawInfo := range index.versions {
 		v, err := semver.NewVersion(rawInfo.Version)
 		if err != nil {
@@ -115,45 +216,75 @@ func findViableVersion(ctx cocov.Context, base string, c constraints, index *ver
 			return nil, err
 		}
 
-		if ok := c.eval(v); ok {
-			return v, nil
+		if ok := c.eval(v); !ok {
+			continue
+		}
+
+		if newest == nil || v.GreaterThan(newest) {
+			newest, newestInfo = v, rawInfo
+		}
+
+		if oldest == nil || v.LessThan(oldest) {
+			oldest, oldestInfo = v, rawInfo
+		}
+
+		if rawInfo.LTS != "" && (newestLTS == nil || v.GreaterThan(newestLTS)) {
+			newestLTS, newestLTSInfo = v, rawInfo
+		}
+	}
+
+	if newest == nil {
+		return nil, fmt.Errorf("no compatible versions found for %s", base)
+	}
+
+	v, info := newest, newestInfo
+	switch policy {
+	case policyOldest:
+		v, info = oldest, oldestInfo
+
+	case policyNewestLTS:
+		if newestLTS != nil {
+			v, info = newestLTS, newestLTSInfo
+		} else {
+			ctx.L().Info("no LTS release satisfies constraint, using newest release",
+				zap.String("constraint", base),
+			)
 		}
 	}
 
-	return nil, fmt.Errorf("no compatible versions found for %s", base)
+	ctx.L().Info("selected node version",
+		zap.String("constraint", base),
+		zap.String("version", info.Version),
+		zap.String("lts", string(info.LTS)),
+		zap.String("date", info.Date),
+	)
+
+	return v, nil
 }
 
-func downloadNode(ctx cocov.Context, url string, repoNodePath string) (string, error) {
+func downloadNode(ctx cocov.Context, url string, installPath string) (string, error) {
 	fileName := "node.tar.gz"
 
-	if err := os.Mkdir(repoNodePath, os.ModePerm); err != nil {
+	if err := os.MkdirAll(installPath, os.ModePerm); err != nil {
 		ctx.L().Error("error creating directory",
-			zap.String("path", repoNodePath),
+			zap.String("path", installPath),
 			zap.Error(err),
 		)
 		return "", err
 	}
 
 	ctx.L().Info("downloading node", zap.String("url", url))
-	resp, err := grequests.Get(url, nil)
-	if err != nil {
+	tarPath := filepath.Join(installPath, fileName)
+	if err := fetchToFile(url, tarPath); err != nil {
 		ctx.L().Error("error downloading node", zap.Error(err))
 		return "", err
 	}
 
-	defer resp.Close()
-
-	tarPath := filepath.Join(repoNodePath, fileName)
-	if err = resp.DownloadToFile(tarPath); err != nil {
-		ctx.L().Error("error writing downloaded node to file", zap.Error(err))
-		return "", err
-	}
-
 	return tarPath, nil
 }
 
-func untar(ctx cocov.Context, e Exec, filePath string, repoNodePath string) error {
-	args := []string{"zxf", filePath, "--strip", "1", "-C", repoNodePath}
+func untar(ctx cocov.Context, e Exec, filePath string, installPath string) error {
+	args := []string{"zxf", filePath, "--strip", "1", "-C", installPath}
 	if _, err := e.Exec("tar", args, nil); err != nil {
 		ctx.L().Error("error extracting downloaded file", zap.Error(err))
 		return err
@@ -179,20 +310,38 @@ func errLockFileNotFound() error {
 	return fmt.Errorf(msg)
 }
 
-func downloadURL(version *semver.Version) string {
+func downloadURL(version *semver.Version, platform string) string {
+	r := strings.NewReplacer("{version}", nodeVersionTag(version), "{platform}", platform)
+	return r.Replace(nodeDownloadTemplate(platform))
+}
+
+// checksumsURL returns the location of the SHASUMS256.txt file listing the
+// digest of the Node archive of version.
+func checksumsURL(version *semver.Version, platform string) string {
+	r := strings.NewReplacer("{version}", nodeVersionTag(version), "{platform}", platform)
+	return r.Replace(nodeChecksumsTemplate(platform))
+}
+
+// archiveName returns the name the Node archive of version is listed by in
+// SHASUMS256.txt.
+func archiveName(version *semver.Version, platform string) string {
+	return fmt.Sprintf("node-%s-%s.tar.gz", nodeVersionTag(version), platform)
+}
+
+// nodeVersionTag returns version as named by Node releases, such as v18.16.0.
+func nodeVersionTag(version *semver.Version) string {
 	strVersion := version.String()
 	if !strings.HasPrefix(strVersion, "v") {
 		strVersion = "v" + strVersion
 	}
 
-	return fmt.Sprintf("https://nodejs.org/dist/%s/node-%s-linux-x64.tar.gz",
-		strVersion, strVersion)
+	return strVersion
 }
 
 var errNoPkgJson = errors.New("package.json not found")
-var errNoVersionFound = errors.New("failed to determine node version using package.json")
-var errNoEslintDep = errors.New("eslint not found as a project dependency")
+var errNoVersionFound = errors.New("failed to determine node version using .nvmrc, .node-version or package.json")
+var errNoEslintDep = fmt.Errorf("eslint not found in node_modules. declare it as a dependency or set %s", envEslintVersion)
 
-func toolCacheKey(version string) string {
-	return fmt.Sprintf("node-%s-linux-x64", version)
+func toolCacheKey(version *semver.Version, platform string) string {
+	return fmt.Sprintf("node-v%s-%s", version.String(), platform)
 }
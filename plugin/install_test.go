@@ -1,69 +1,63 @@
 package plugin
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/Masterminds/semver"
+	"github.com/golang/mock/gomock"
 	"github.com/heyvito/httpie"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 func TestDetermineNodeVersion(t *testing.T) {
-	toEval, versionErr := semver.NewVersion("v9")
-	require.NoError(t, versionErr)
+	version := func(t *testing.T, v string) *semver.Version {
+		sv, err := semver.NewVersion(v)
+		require.NoError(t, err)
+		return sv
+	}
 
-	t.Run("Returns a single constraint", func(t *testing.T) {
-		v := "v9.x"
-		constr, err := determineVersionConstraints(v)
+	t.Run("Returns a single comparator set", func(t *testing.T) {
+		constr, err := determineVersionConstraints("v9.x")
 		assert.NoError(t, err)
 		assert.Len(t, constr, 1)
+		assert.True(t, constr.eval(version(t, "v9")))
+	})
 
-		ok := constr.eval(toEval)
-		assert.True(t, ok)
-
-		// Semver is able to handle this case as a single constraint
-		v = "^8.x || ^10.x"
-		constr, err = determineVersionConstraints(v)
+	t.Run("Returns a comparator set for each alternative", func(t *testing.T) {
+		constr, err := determineVersionConstraints("^8.x || ^10.x")
 		assert.NoError(t, err)
-		assert.Len(t, constr, 1)
-
-		ok = constr.eval(toEval)
-		assert.False(t, ok)
+		assert.Len(t, constr, 2)
 
-		ev1, err := semver.NewVersion("v8.9")
-		require.NoError(t, err)
-
-		ev2, err := semver.NewVersion("v10.2")
-		require.NoError(t, err)
-
-		ok = constr.eval(ev1, ev2)
-		assert.True(t, ok)
+		assert.False(t, constr.eval(version(t, "v9")))
+		assert.True(t, constr.eval(version(t, "v8.9"), version(t, "v10.2")))
 	})
 
-	t.Run("Returns n constraints", func(t *testing.T) {
-		v := ">=v12.x <=v13.4.x "
-		constrs, err := determineVersionConstraints(v)
+	t.Run("Requires all comparators of a set", func(t *testing.T) {
+		constr, err := determineVersionConstraints(">=v12.x <=v13.4.x ")
 		assert.NoError(t, err)
-		assert.Len(t, constrs, 2)
-
-		v = "^8.x  <=10.x"
-		constrs, err = determineVersionConstraints(v)
-		assert.NoError(t, err)
-		assert.Len(t, constrs, 2)
+		assert.Len(t, constr, 1)
 
-		ok := constrs.eval(toEval)
-		assert.True(t, ok)
+		assert.True(t, constr.eval(version(t, "v12.5")))
+		assert.True(t, constr.eval(version(t, "v13.4.9")))
+		assert.False(t, constr.eval(version(t, "v13.5")))
 
-		ev1, err := semver.NewVersion("v8.9")
-		require.NoError(t, err)
+		constr, err = determineVersionConstraints("^8.x  <=10.x")
+		assert.NoError(t, err)
+		assert.Len(t, constr, 1)
 
-		ev2, err := semver.NewVersion("v10.2")
-		require.NoError(t, err)
+		assert.True(t, constr.eval(version(t, "v8.9")))
+		assert.False(t, constr.eval(version(t, "v9")))
+	})
 
-		ok = constrs.eval(ev1, ev2)
-		assert.True(t, ok)
+	t.Run("Reports invalid ranges", func(t *testing.T) {
+		_, err := determineVersionConstraints(">=14 <1a")
+		assert.ErrorContains(t, err, "column 8")
 	})
 }
 
@@ -94,10 +88,116 @@ func TestGetNodeVersionIndex(t *testing.T) {
 	})
 
 	t.Run("Works as expected", func(t *testing.T) {
+		server := httpie.New(httpie.WithBytes("/index.json", "application/json", nodeIndexFixture(t)))
+		defer server.Stop()
+
+		helper := newTestHelper(t)
+		vi, err := getNodeVersionIndex(helper.ctx, server.URL+"/index.json")
+		require.NoError(t, err)
+		require.NotNil(t, vi)
+		assert.NotEmpty(t, vi.versions)
+	})
+
+	t.Run("Works with a local mirror", func(t *testing.T) {
+		dir := t.TempDir()
+		err := os.WriteFile(filepath.Join(dir, "index.json"), nodeIndexFixture(t), os.ModePerm)
+		require.NoError(t, err)
+
+		t.Setenv(envNodeMirror, "file://"+dir+"/")
+
 		helper := newTestHelper(t)
-		vi, err := getNodeVersionIndex(helper.ctx, nodeIndex)
+		vi, err := getNodeVersionIndex(helper.ctx, nodeIndexURL("linux-x64"))
+		require.NoError(t, err)
+		require.NotNil(t, vi)
+		assert.NotEmpty(t, vi.versions)
+
+		t.Setenv(envNodeMirror, dir)
+
+		vi, err = getNodeVersionIndex(helper.ctx, nodeIndexURL("linux-x64"))
 		assert.NoError(t, err)
-		assert.NotNil(t, vi)
+		assert.NotEmpty(t, vi.versions)
+	})
+}
+
+func TestDownloadURL(t *testing.T) {
+	v, err := semver.NewVersion("18.16.0")
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://nodejs.org/dist/v18.16.0/node-v18.16.0-linux-x64.tar.gz", downloadURL(v, "linux-x64"))
+
+	t.Setenv(envNodeMirror, "https://mirror.example.com/node/")
+	assert.Equal(t, "https://mirror.example.com/node/v18.16.0/node-v18.16.0-linux-x64.tar.gz", downloadURL(v, "linux-x64"))
+	assert.Equal(t, "https://mirror.example.com/node/index.json", nodeIndexURL("linux-x64"))
+
+	t.Setenv(envNodeDownloadURL, "https://artifacts.example.com/node-{version}-{platform}.tgz")
+	assert.Equal(t, "https://artifacts.example.com/node-v18.16.0-linux-x64.tgz", downloadURL(v, "linux-x64"))
+}
+
+func TestChecksumsURL(t *testing.T) {
+	v, err := semver.NewVersion("18.16.0")
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://nodejs.org/dist/v18.16.0/SHASUMS256.txt", checksumsURL(v, "linux-x64"))
+	assert.Equal(t, "node-v18.16.0-linux-x64.tar.gz", archiveName(v, "linux-x64"))
+
+	// Templated downloads keep reading checksums from the mirror
+	t.Setenv(envNodeMirror, "https://mirror.example.com/node")
+	t.Setenv(envNodeDownloadURL, "https://artifacts.example.com/node-{version}-{platform}.tgz")
+	assert.Equal(t, "https://mirror.example.com/node/v18.16.0/SHASUMS256.txt", checksumsURL(v, "linux-x64"))
+
+	t.Setenv(envNodeChecksumsURL, "https://artifacts.example.com/{version}.sha256")
+	assert.Equal(t, "https://artifacts.example.com/v18.16.0.sha256", checksumsURL(v, "linux-x64"))
+}
+
+func TestMuslMirror(t *testing.T) {
+	v, err := semver.NewVersion("18.16.0")
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://unofficial-builds.nodejs.org/download/release/index.json", nodeIndexURL("linux-x64-musl"))
+	assert.Equal(t, "https://unofficial-builds.nodejs.org/download/release/v18.16.0/node-v18.16.0-linux-x64-musl.tar.gz",
+		downloadURL(v, "linux-x64-musl"))
+}
+
+func TestDownloadNode(t *testing.T) {
+	archive := []byte("node archive")
+	server := httpie.New(httpie.WithCustom("/v18.16.0/node-v18.16.0-linux-x64.tar.gz", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(archive)
+	}))
+	defer server.Stop()
+
+	t.Run("Downloads from a HTTP mirror", func(t *testing.T) {
+		helper := newTestHelper(t)
+		target := filepath.Join(t.TempDir(), "node")
+
+		p, err := downloadNode(helper.ctx, server.URL+"/v18.16.0/node-v18.16.0-linux-x64.tar.gz", target)
+		require.NoError(t, err)
+
+		data, err := os.ReadFile(p)
+		require.NoError(t, err)
+		assert.Equal(t, archive, data)
+	})
+
+	t.Run("Copies from a local mirror", func(t *testing.T) {
+		src := filepath.Join(t.TempDir(), "node-v18.16.0-linux-x64.tar.gz")
+		require.NoError(t, os.WriteFile(src, archive, os.ModePerm))
+
+		helper := newTestHelper(t)
+		target := filepath.Join(t.TempDir(), "node")
+
+		p, err := downloadNode(helper.ctx, "file://"+src, target)
+		require.NoError(t, err)
+
+		data, err := os.ReadFile(p)
+		require.NoError(t, err)
+		assert.Equal(t, archive, data)
+	})
+
+	t.Run("Fails on missing archives", func(t *testing.T) {
+		helper := newTestHelper(t)
+		target := filepath.Join(t.TempDir(), "node")
+
+		_, err := downloadNode(helper.ctx, server.URL+"/v20.0.0/node-v20.0.0-linux-x64.tar.gz", target)
+		assert.Error(t, err)
 	})
 }
 
@@ -116,7 +216,7 @@ func TestFindViableVersion(t *testing.T) {
 
 		helper := newTestHelper(t)
 
-		_, err = findViableVersion(helper.ctx, rawVer, constr, vi)
+		_, err = findViableVersion(helper.ctx, rawVer, constr, vi, policyNewestLTS)
 		assert.Error(t, err)
 	})
 
@@ -127,7 +227,121 @@ func TestFindViableVersion(t *testing.T) {
 
 		helper := newTestHelper(t)
 
-		_, err = findViableVersion(helper.ctx, rawVer, constr, vi)
+		_, err = findViableVersion(helper.ctx, rawVer, constr, vi, policyNewestLTS)
 		assert.NoError(t, err)
 	})
 }
+
+func TestFindViableVersionPolicies(t *testing.T) {
+	vi := &versionIndex{}
+	require.NoError(t, json.Unmarshal(nodeIndexFixture(t), &vi.versions))
+
+	tests := []struct {
+		constraint string
+		policy     versionPolicy
+		expected   string
+	}{
+		{">=16", policyNewestLTS, "18.16.0"},
+		{">=16", policyNewest, "20.2.0"},
+		{">=16", policyOldest, "16.12.0"},
+		{"^18", policyNewestLTS, "18.16.0"},
+		{"^18", policyOldest, "18.0.0"},
+		{"19", policyNewestLTS, "19.9.0"},
+		{"<15", policyNewestLTS, "14.21.3"},
+		{"<15", policyOldest, "12.22.12"},
+		{"~16.12", policyNewestLTS, "16.12.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s/%d", tt.constraint, tt.policy), func(t *testing.T) {
+			constr, err := determineVersionConstraints(tt.constraint)
+			require.NoError(t, err)
+
+			helper := newTestHelper(t)
+
+			v, err := findViableVersion(helper.ctx, tt.constraint, constr, vi, tt.policy)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, v.String())
+		})
+	}
+}
+
+func TestNodeVersionPolicy(t *testing.T) {
+	p, err := nodeVersionPolicy()
+	require.NoError(t, err)
+	assert.Equal(t, policyNewestLTS, p)
+
+	t.Setenv(envNodeVersionPolicy, "Oldest")
+	p, err = nodeVersionPolicy()
+	require.NoError(t, err)
+	assert.Equal(t, policyOldest, p)
+
+	t.Setenv(envNodeVersionPolicy, "bleeding-edge")
+	_, err = nodeVersionPolicy()
+	assert.Error(t, err)
+}
+
+func TestNodeInstallPath(t *testing.T) {
+	v18, err := semver.NewVersion("v18.16.0")
+	require.NoError(t, err)
+
+	other, err := semver.NewVersion("18.16.0")
+	require.NoError(t, err)
+
+	v20, err := semver.NewVersion("20.2.0")
+	require.NoError(t, err)
+
+	assert.Equal(t, nodeInstallPath(v18, "linux-x64"), nodeInstallPath(other, "linux-x64"))
+	assert.Equal(t, filepath.Join(nodePath, "node-v18.16.0-linux-x64"), nodeInstallPath(v18, "linux-x64"))
+	assert.NotEqual(t, nodeInstallPath(v18, "linux-x64"), nodeInstallPath(v18, "linux-arm64"))
+	assert.NotEqual(t, nodeInstallPath(v18, "linux-x64"), nodeInstallPath(v20, "linux-x64"))
+}
+
+func TestRootNodePath(t *testing.T) {
+	t.Cleanup(func() {
+		indexMemo = map[string]*versionIndex{}
+		installedNodes = map[string]bool{}
+	})
+
+	index := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(index, "index.json"), nodeIndexFixture(t), os.ModePerm))
+	t.Setenv(envNodeIndexURL, "file://"+filepath.Join(index, "index.json"))
+	t.Setenv(envNodeIndexTTL, "0")
+
+	workspace := func(t *testing.T, rootPkg string) project {
+		root := t.TempDir()
+		member := filepath.Join(root, "packages", "a")
+		require.NoError(t, os.MkdirAll(member, os.ModePerm))
+		require.NoError(t, os.WriteFile(filepath.Join(root, pkgJson), []byte(rootPkg), os.ModePerm))
+		require.NoError(t, os.WriteFile(filepath.Join(member, pkgJson), []byte(`{"engines": {"node": "18.x"}}`), os.ModePerm))
+		return project{path: member, root: root}
+	}
+
+	t.Run("Uses the path of standalone packages", func(t *testing.T) {
+		helper := newTestHelper(t)
+		dir := t.TempDir()
+
+		np, err := rootNodePath(helper.ctx, helper.exec, project{path: dir, root: dir}, "member-path", &nodeDefault{})
+		require.NoError(t, err)
+		assert.Equal(t, "member-path", np)
+	})
+
+	t.Run("Uses the version required by the root", func(t *testing.T) {
+		helper := newTestHelper(t)
+		p := workspace(t, `{"workspaces": ["packages/*"], "engines": {"node": "20.x"}}`)
+		helper.ctx.EXPECT().LoadToolCache(gomock.Any(), gomock.Any()).Return(true)
+
+		np, err := rootNodePath(helper.ctx, helper.exec, p, "member-path", &nodeDefault{})
+		require.NoError(t, err)
+		assert.Contains(t, np, "node-v20.")
+	})
+
+	t.Run("Falls back to the member version", func(t *testing.T) {
+		helper := newTestHelper(t)
+		p := workspace(t, `{"workspaces": ["packages/*"]}`)
+
+		np, err := rootNodePath(helper.ctx, helper.exec, p, "member-path", &nodeDefault{})
+		require.NoError(t, err)
+		assert.Equal(t, "member-path", np)
+	})
+}
@@ -0,0 +1,111 @@
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/cocov-ci/go-plugin-kit/cocov"
+	"gopkg.in/yaml.v3"
+)
+
+// configFile is the repository file configuring the plugin. It customizes
+// how rules are reported, and may set the default Node version of the
+// repository.
+var configFile = filepath.Join(".cocov", "eslint.yaml")
+
+// kindConfig holds user-provided overrides of the kinds of rules. Rules are
+// referred to either by their IDs or by patterns as understood by
+// path.Match, such as security/*.
+type kindConfig struct {
+	Kinds   map[string]string `yaml:"kinds"`
+	Default string            `yaml:"default"`
+	Ignore  []string          `yaml:"ignore"`
+
+	kinds       map[string]cocov.IssueKind
+	defaultKind *cocov.IssueKind
+}
+
+// loadKindConfig reads the kind configuration of the repository at
+// rootPath. An empty configuration is returned when the file is missing.
+func loadKindConfig(rootPath string) (*kindConfig, error) {
+	cfg := &kindConfig{kinds: map[string]cocov.IssueKind{}}
+
+	data, err := os.ReadFile(filepath.Join(rootPath, configFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return cfg, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err = yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", configFile, err)
+	}
+
+	for pattern, name := range cfg.Kinds {
+		if _, err = path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid %s: pattern %q: %w", configFile, pattern, err)
+		}
+
+		kind, ok := issueKinds[strings.ToLower(name)]
+		if !ok {
+			return nil, fmt.Errorf("invalid %s: unknown kind %q for %s", configFile, name, pattern)
+		}
+		cfg.kinds[pattern] = kind
+	}
+
+	for _, pattern := range cfg.Ignore {
+		if _, err = path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid %s: pattern %q: %w", configFile, pattern, err)
+		}
+	}
+
+	if cfg.Default != "" {
+		kind, ok := issueKinds[strings.ToLower(cfg.Default)]
+		if !ok {
+			return nil, fmt.Errorf("invalid %s: unknown default kind %q", configFile, cfg.Default)
+		}
+		cfg.defaultKind = &kind
+	}
+
+	return cfg, nil
+}
+
+// ignores reports whether rule is part of the ignore list.
+func (c *kindConfig) ignores(rule string) bool {
+	for _, pattern := range c.Ignore {
+		if ok, _ := path.Match(pattern, rule); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// kindFor returns the kind configured for rule. Rule IDs take precedence
+// over patterns, and longer patterns take precedence over shorter ones.
+func (c *kindConfig) kindFor(rule string) (cocov.IssueKind, bool) {
+	if kind, ok := c.kinds[rule]; ok {
+		return kind, true
+	}
+
+	best := ""
+	for pattern := range c.kinds {
+		ok, _ := path.Match(pattern, rule)
+		if ok && (len(pattern) > len(best) || (len(pattern) == len(best) && pattern < best)) {
+			best = pattern
+		}
+	}
+
+	if best == "" {
+		return 0, false
+	}
+
+	return c.kinds[best], true
+}
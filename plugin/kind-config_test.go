@@ -0,0 +1,123 @@
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cocov-ci/go-plugin-kit/cocov"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeKindConfig(t *testing.T, content string) string {
+	root := t.TempDir()
+	file := filepath.Join(root, configFile)
+	require.NoError(t, os.MkdirAll(filepath.Dir(file), os.ModePerm))
+	require.NoError(t, os.WriteFile(file, []byte(content), os.ModePerm))
+	return root
+}
+
+func TestLoadKindConfig(t *testing.T) {
+	t.Run("Missing file", func(t *testing.T) {
+		cfg, err := loadKindConfig(t.TempDir())
+		require.NoError(t, err)
+
+		_, ok := cfg.kindFor("semi")
+		assert.False(t, ok)
+		assert.False(t, cfg.ignores("semi"))
+	})
+
+	t.Run("Rejects unknown kinds", func(t *testing.T) {
+		root := writeKindConfig(t, "kinds:\n  semi: cosmetic\n")
+		_, err := loadKindConfig(root)
+		assert.ErrorContains(t, err, "cosmetic")
+	})
+
+	t.Run("Rejects invalid patterns", func(t *testing.T) {
+		root := writeKindConfig(t, "ignore:\n  - \"[security\"\n")
+		_, err := loadKindConfig(root)
+		assert.Error(t, err)
+	})
+}
+
+func TestKindOverrides(t *testing.T) {
+	root := writeKindConfig(t, `
+kinds:
+  security/*: security
+  security/detect-eval-with-expression: bug
+  "@typescript-eslint/no-floating-promises": Bug
+  "@typescript-eslint/*": quality
+  semi: performance
+default: convention
+ignore:
+  - no-console
+  - legacy/*
+`)
+
+	cfg, err := loadKindConfig(root)
+	require.NoError(t, err)
+
+	out := newCliOutput()
+	out.Metadata.RulesMeta["quotes"] = metadataInfo{Type: "layout"}
+	policy := warningPolicy{}
+
+	tests := map[string]struct {
+		rule     string
+		expected cocov.IssueKind
+		ok       bool
+	}{
+		"Pattern":           {"security/detect-object-injection", cocov.IssueKindSecurity, true},
+		"Rule over pattern": {"security/detect-eval-with-expression", cocov.IssueKindBug, true},
+		"Scoped rule":       {"@typescript-eslint/no-floating-promises", cocov.IssueKindBug, true},
+		"Scoped pattern":    {"@typescript-eslint/no-explicit-any", cocov.IssueKindQuality, true},
+		"Over known kinds":  {"semi", cocov.IssueKindPerformance, true},
+		"Known kinds":       {"quotes", cocov.IssueKindStyle, true},
+		"Default kind":      {"custom/unknown-rule", cocov.IssueKindConvention, true},
+		"Ignored rule":      {"no-console", 0, false},
+		"Ignored pattern":   {"legacy/no-var", 0, false},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := message{RuleID: tt.rule, Severity: severityError}
+			kind, ok := out.kindForMessage(m, policy, cfg)
+			assert.Equal(t, tt.ok, ok)
+			if tt.ok {
+				assert.Equal(t, tt.expected, kind)
+			}
+		})
+	}
+
+	t.Run("Reports fatal messages", func(t *testing.T) {
+		m := message{Severity: severityError, Fatal: true}
+		kind, ok := out.kindForMessage(m, policy, cfg)
+		assert.True(t, ok)
+		assert.Equal(t, cocov.IssueKindBug, kind)
+	})
+}
+
+func TestLoadNodeDefault(t *testing.T) {
+	t.Run("Missing file", func(t *testing.T) {
+		def, err := loadNodeDefault(t.TempDir())
+		require.NoError(t, err)
+		assert.Empty(t, def.version)
+	})
+
+	t.Run("Reads the repository file", func(t *testing.T) {
+		root := writeKindConfig(t, "default_node_version: \">=18\"\n")
+		def, err := loadNodeDefault(root)
+		require.NoError(t, err)
+		assert.Equal(t, ">=18", def.version)
+		assert.Equal(t, ".cocov/eslint.yaml", def.setting)
+	})
+
+	t.Run("Prefers the environment", func(t *testing.T) {
+		t.Setenv(envDefaultNodeVersion, "latest LTS")
+		root := writeKindConfig(t, "default_node_version: \">=18\"\n")
+		def, err := loadNodeDefault(root)
+		require.NoError(t, err)
+		assert.Equal(t, "lts/*", def.version)
+		assert.Equal(t, envDefaultNodeVersion, def.setting)
+	})
+}
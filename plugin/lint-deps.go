@@ -0,0 +1,374 @@
+package plugin
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/cocov-ci/go-plugin-kit/cocov"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+// lintSupportPackages are required by common parsers and plugins, despite
+// not being named after eslint.
+var lintSupportPackages = map[string]bool{
+	"typescript":  true,
+	"prettier":    true,
+	"@babel/core": true,
+	"globals":     true,
+}
+
+// dependencyFields are the package.json fields trimmed by lint-only installs.
+var dependencyFields = []string{"dependencies", "devDependencies", "optionalDependencies"}
+
+var stringLiteral = regexp.MustCompile("\"([^\"\\\\\\n]+)\"|'([^'\\\\\\n]+)'|`([^`\\\\$]+)`")
+
+// lintDependencies returns the dependencies declared by pkg required to run
+// eslint: eslint itself, packages referenced by the eslint configuration,
+// such as plugins, parsers and shareable configs, and packages whose names
+// suggest they belong to the eslint ecosystem.
+func lintDependencies(repoPath string, pkg map[string]json.RawMessage) (map[string]bool, error) {
+	refs, err := configReferences(repoPath, pkg)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := map[string]bool{}
+	for _, ref := range refs {
+		for _, name := range referencedPackages(ref) {
+			candidates[name] = true
+		}
+	}
+
+	keep := map[string]bool{}
+	for _, field := range dependencyFields {
+		deps := map[string]string{}
+		if raw, ok := pkg[field]; ok {
+			if err = json.Unmarshal(raw, &deps); err != nil {
+				return nil, err
+			}
+		}
+
+		for name := range deps {
+			if candidates[name] || isLintPackage(name) {
+				keep[name] = true
+			}
+		}
+	}
+
+	return keep, nil
+}
+
+func isLintPackage(name string) bool {
+	return strings.Contains(name, "eslint") || lintSupportPackages[name]
+}
+
+// configReferences returns the strings present in the eslint configuration
+// files of the project at repoPath, and in the eslintConfig field of its
+// package.json. Configurations written in JavaScript cannot be evaluated,
+// and have their string literals extracted instead.
+func configReferences(repoPath string, pkg map[string]json.RawMessage) ([]string, error) {
+	var refs []string
+	if raw, ok := pkg["eslintConfig"]; ok {
+		var v any
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return nil, err
+		}
+		refs = collectStrings(v, refs)
+	}
+
+	for _, name := range append(flatConfigFiles, legacyConfigFiles...) {
+		data, err := os.ReadFile(filepath.Join(repoPath, name))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		var v any
+		if ext := filepath.Ext(name); ext == ".yaml" || ext == ".yml" || ext == ".json" || name == ".eslintrc" {
+			// YAML parses JSON as well, except for the comments eslintrc
+			// allows, which are handled as JavaScript.
+			if err = yaml.Unmarshal(data, &v); err == nil {
+				refs = collectStrings(v, refs)
+				continue
+			}
+		}
+
+		for _, m := range stringLiteral.FindAllStringSubmatch(string(data), -1) {
+			refs = append(refs, m[1]+m[2]+m[3])
+		}
+	}
+
+	return refs, nil
+}
+
+// collectStrings appends every string found in v, including map keys such
+// as rule names, to refs.
+func collectStrings(v any, refs []string) []string {
+	switch t := v.(type) {
+	case string:
+		refs = append(refs, t)
+	case []any:
+		for _, i := range t {
+			refs = collectStrings(i, refs)
+		}
+	case map[string]any:
+		for k, i := range t {
+			refs = append(refs, k)
+			refs = collectStrings(i, refs)
+		}
+	}
+
+	return refs
+}
+
+// referencedPackages returns the packages ref may refer to. Besides module
+// specifiers, eslintrc configurations refer to plugins and shareable configs
+// by short names, such as react for eslint-plugin-react, plugin:react/all
+// for one of its configs and react/jsx-key for one of its rules.
+func referencedPackages(ref string) []string {
+	ref = strings.TrimSpace(ref)
+	if strings.HasPrefix(ref, "plugin:") {
+		plugin := strings.TrimPrefix(ref, "plugin:")
+		if idx := strings.LastIndex(plugin, "/"); idx > 0 {
+			plugin = plugin[:idx]
+		}
+		return []string{packageWithPrefix(plugin, "eslint-plugin")}
+	}
+
+	module := moduleName(ref)
+	if module == "" {
+		return nil
+	}
+
+	names := []string{
+		module,
+		packageWithPrefix(module, "eslint-plugin"),
+		packageWithPrefix(module, "eslint-config"),
+	}
+
+	if scope, _, ok := strings.Cut(module, "/"); ok && strings.HasPrefix(scope, "@") {
+		names = append(names,
+			packageWithPrefix(scope, "eslint-plugin"),
+			packageWithPrefix(scope, "eslint-config"),
+		)
+	}
+
+	return names
+}
+
+// moduleName returns the package name of a module specifier such as
+// @scope/name/sub or name/sub, or an empty string for relative paths and
+// builtin modules.
+func moduleName(spec string) string {
+	if spec == "" || strings.ContainsAny(spec, ": \t\n") ||
+		strings.HasPrefix(spec, ".") || strings.HasPrefix(spec, "/") {
+		return ""
+	}
+
+	parts := strings.Split(spec, "/")
+	if strings.HasPrefix(spec, "@") && len(parts) > 1 {
+		return parts[0] + "/" + parts[1]
+	}
+
+	return parts[0]
+}
+
+// packageWithPrefix expands the short name of a plugin or shareable config
+// following eslint's naming conventions: x becomes <prefix>-x, @scope becomes
+// @scope/<prefix> and @scope/x becomes @scope/<prefix>-x.
+func packageWithPrefix(name, prefix string) string {
+	if strings.HasPrefix(name, "@") {
+		scope, rest, ok := strings.Cut(name, "/")
+		switch {
+		case !ok:
+			return scope + "/" + prefix
+		case strings.HasPrefix(rest, prefix):
+			return name
+		}
+		return scope + "/" + prefix + "-" + rest
+	}
+
+	if strings.HasPrefix(name, prefix) {
+		return name
+	}
+
+	return prefix + "-" + name
+}
+
+// trimToLintDependencies rewrites the package.json file at repoPath, and the
+// ones of its workspace members, keeping only the dependencies required to
+// run eslint. The returned function puts back the original package.json
+// files along with any lockfile changed, or created, by the following
+// install.
+func trimToLintDependencies(ctx cocov.Context, repoPath string) (func() error, error) {
+	members, err := workspaceMembers(repoPath)
+	if err != nil {
+		ctx.L().Error("failed to find workspace members", zap.Error(err))
+		return nil, err
+	}
+
+	restoreLocks, err := snapshotLockFiles(repoPath)
+	if err != nil {
+		ctx.L().Error("failed to read lock files", zap.Error(err))
+		return nil, err
+	}
+
+	originals := map[string][]byte{}
+	restore := func() error {
+		for file, data := range originals {
+			if err := os.WriteFile(file, data, os.ModePerm); err != nil {
+				return err
+			}
+		}
+		return restoreLocks()
+	}
+
+	for _, dir := range append([]string{repoPath}, members...) {
+		jsonFile := filepath.Join(dir, pkgJson)
+		original, err := trimManifest(ctx, dir)
+		if err != nil {
+			if rErr := restore(); rErr != nil {
+				ctx.L().Error("failed to restore package.json", zap.Error(rErr))
+			}
+			return nil, err
+		}
+		originals[jsonFile] = original
+	}
+
+	return restore, nil
+}
+
+// workspaceMembers returns the directories of the workspace members of the
+// root at rootPath, if it declares any.
+func workspaceMembers(rootPath string) ([]string, error) {
+	patterns, ok, err := workspacePatterns(rootPath)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	repos, err := findRepositories(rootPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var members []string
+	for _, repo := range repos {
+		if repo != filepath.Clean(rootPath) && isWorkspaceMember(rootPath, repo, patterns) {
+			members = append(members, repo)
+		}
+	}
+
+	return members, nil
+}
+
+// trimManifest rewrites the package.json file in dir keeping only the
+// dependencies required to run eslint, and returns its original contents.
+func trimManifest(ctx cocov.Context, dir string) ([]byte, error) {
+	jsonFile := filepath.Join(dir, pkgJson)
+	original, err := os.ReadFile(jsonFile)
+	if err != nil {
+		ctx.L().Error("failed to read package.json", zap.Error(err))
+		return nil, err
+	}
+
+	pkg := map[string]json.RawMessage{}
+	if err = json.Unmarshal(original, &pkg); err != nil {
+		ctx.L().Error("failed to unmarshall package.json", zap.Error(err))
+		return nil, err
+	}
+
+	keep, err := lintDependencies(dir, pkg)
+	if err != nil {
+		ctx.L().Error("failed to determine lint dependencies", zap.Error(err))
+		return nil, err
+	}
+
+	for _, field := range dependencyFields {
+		raw, ok := pkg[field]
+		if !ok {
+			continue
+		}
+
+		deps := map[string]string{}
+		if err = json.Unmarshal(raw, &deps); err != nil {
+			return nil, err
+		}
+
+		for name := range deps {
+			if !keep[name] {
+				delete(deps, name)
+			}
+		}
+
+		if pkg[field], err = json.Marshal(deps); err != nil {
+			return nil, err
+		}
+	}
+
+	data, err := json.MarshalIndent(pkg, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(keep))
+	for name := range keep {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	ctx.L().Info("installing lint dependencies only",
+		zap.String("path", dir),
+		zap.Strings("dependencies", names),
+	)
+
+	if err = os.WriteFile(jsonFile, data, os.ModePerm); err != nil {
+		ctx.L().Error("failed to write package.json", zap.Error(err))
+		return nil, err
+	}
+
+	return original, nil
+}
+
+// snapshotLockFiles records the lockfiles present in repoPath. The returned
+// function restores their contents, and removes lockfiles created since.
+func snapshotLockFiles(repoPath string) (func() error, error) {
+	contents := map[string][]byte{}
+	for file := range managers {
+		data, err := os.ReadFile(filepath.Join(repoPath, file))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+		contents[file] = data
+	}
+
+	return func() error {
+		for file := range managers {
+			path := filepath.Join(repoPath, file)
+			data, ok := contents[file]
+			if !ok {
+				if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+					return err
+				}
+				continue
+			}
+
+			if err := os.WriteFile(path, data, os.ModePerm); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, nil
+}
@@ -0,0 +1,156 @@
+package plugin
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReferencedPackages(t *testing.T) {
+	tests := map[string][]string{
+		"plugin:react/recommended":              {"eslint-plugin-react"},
+		"plugin:@typescript-eslint/recommended": {"@typescript-eslint/eslint-plugin"},
+		"airbnb":                                {"airbnb", "eslint-plugin-airbnb", "eslint-config-airbnb"},
+		"@vue/typescript/recommended": {
+			"@vue/typescript",
+			"@vue/eslint-plugin-typescript",
+			"@vue/eslint-config-typescript",
+			"@vue/eslint-plugin",
+			"@vue/eslint-config",
+		},
+		"./local-rules": nil,
+		"node:path":     nil,
+	}
+
+	for ref, expected := range tests {
+		t.Run(ref, func(t *testing.T) {
+			assert.Equal(t, expected, referencedPackages(ref))
+		})
+	}
+}
+
+func TestTrimToLintDependencies(t *testing.T) {
+	writeFixture := func(t *testing.T, dir, name, content string) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), os.ModePerm))
+	}
+
+	readDeps := func(t *testing.T, dir string) map[string]map[string]string {
+		data, err := os.ReadFile(filepath.Join(dir, pkgJson))
+		require.NoError(t, err)
+
+		pkg := struct {
+			Dependencies    map[string]string `json:"dependencies"`
+			DevDependencies map[string]string `json:"devDependencies"`
+		}{}
+		require.NoError(t, json.Unmarshal(data, &pkg))
+		return map[string]map[string]string{
+			"dependencies":    pkg.Dependencies,
+			"devDependencies": pkg.DevDependencies,
+		}
+	}
+
+	pkg := `{
+  "dependencies": {"vue": "^3.0.0", "lodash": "^4.0.0"},
+  "devDependencies": {
+    "eslint": "^8.0.0",
+    "eslint-plugin-react": "^7.0.0",
+    "eslint-config-standard": "^17.0.0",
+    "@vue/eslint-config-prettier": "^7.0.0",
+    "neostandard": "^0.11.0",
+    "jest": "^29.0.0",
+    "typescript": "^5.0.0"
+  }
+}`
+
+	t.Run("Uses eslintrc references", func(t *testing.T) {
+		helper := newTestHelper(t)
+		dir := t.TempDir()
+		writeFixture(t, dir, pkgJson, pkg)
+		writeFixture(t, dir, ".eslintrc.yml", "extends:\n  - standard\nplugins: [react]\n")
+		writeFixture(t, dir, "package-lock.json", "{}")
+
+		restore, err := trimToLintDependencies(helper.ctx, dir)
+		require.NoError(t, err)
+
+		deps := readDeps(t, dir)
+		assert.Empty(t, deps["dependencies"])
+		assert.Equal(t, map[string]string{
+			"eslint":                      "^8.0.0",
+			"eslint-plugin-react":         "^7.0.0",
+			"eslint-config-standard":      "^17.0.0",
+			"@vue/eslint-config-prettier": "^7.0.0",
+			"typescript":                  "^5.0.0",
+		}, deps["devDependencies"])
+
+		writeFixture(t, dir, "package-lock.json", `{"changed": true}`)
+		writeFixture(t, dir, "yarn.lock", "")
+
+		require.NoError(t, restore())
+
+		data, err := os.ReadFile(filepath.Join(dir, pkgJson))
+		require.NoError(t, err)
+		assert.Equal(t, pkg, string(data))
+
+		data, err = os.ReadFile(filepath.Join(dir, "package-lock.json"))
+		require.NoError(t, err)
+		assert.Equal(t, "{}", string(data))
+		assert.NoFileExists(t, filepath.Join(dir, "yarn.lock"))
+	})
+
+	t.Run("Uses flat config imports", func(t *testing.T) {
+		helper := newTestHelper(t)
+		dir := t.TempDir()
+		writeFixture(t, dir, pkgJson, pkg)
+		writeFixture(t, dir, "eslint.config.mjs", "import neostandard from 'neostandard'\n\nexport default neostandard({})\n")
+
+		restore, err := trimToLintDependencies(helper.ctx, dir)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = restore() })
+
+		deps := readDeps(t, dir)
+		assert.Contains(t, deps["devDependencies"], "neostandard")
+		assert.NotContains(t, deps["devDependencies"], "jest")
+		assert.NotContains(t, deps["dependencies"], "vue")
+	})
+
+	t.Run("Trims workspace members", func(t *testing.T) {
+		helper := newTestHelper(t)
+		dir := t.TempDir()
+		member := filepath.Join(dir, "packages", "a")
+		other := filepath.Join(dir, "tools", "b")
+		require.NoError(t, os.MkdirAll(member, os.ModePerm))
+		require.NoError(t, os.MkdirAll(other, os.ModePerm))
+
+		root := `{"workspaces": ["packages/*"], "devDependencies": {"eslint": "^8.0.0", "jest": "^29.0.0"}}`
+		writeFixture(t, dir, pkgJson, root)
+		writeFixture(t, dir, ".eslintrc.json", "{}")
+		writeFixture(t, member, pkgJson, pkg)
+		writeFixture(t, member, ".eslintrc.yml", "plugins: [react]\n")
+		writeFixture(t, other, pkgJson, pkg)
+
+		restore, err := trimToLintDependencies(helper.ctx, dir)
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]string{"eslint": "^8.0.0"}, readDeps(t, dir)["devDependencies"])
+
+		deps := readDeps(t, member)
+		assert.Empty(t, deps["dependencies"])
+		assert.Contains(t, deps["devDependencies"], "eslint-plugin-react")
+		assert.NotContains(t, deps["devDependencies"], "jest")
+
+		// Packages outside the workspace are left untouched
+		assert.Contains(t, readDeps(t, other)["devDependencies"], "jest")
+
+		require.NoError(t, restore())
+
+		for path, content := range map[string]string{dir: root, member: pkg, other: pkg} {
+			data, err := os.ReadFile(filepath.Join(path, pkgJson))
+			require.NoError(t, err)
+			assert.Equal(t, content, string(data))
+		}
+	})
+}
@@ -1,41 +1,246 @@
 package plugin
 
 import (
+	"errors"
+	"fmt"
 	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/cocov-ci/go-plugin-kit/cocov"
 	"go.uber.org/zap"
 )
 
-func restoreNodeModules(ctx cocov.Context, e Exec, manager, file, nodePath, repoPath string) error {
-	file = filepath.Join(repoPath, file)
-	repoJsonFile := filepath.Join(repoPath, pkgJson)
-	nodeModules := filepath.Join(repoPath, "node_modules")
-	artifactKeys := []string{repoJsonFile, file}
+var errFrozenNoLockFile = errors.New("frozen installs require a lock file")
+var errLockFileDrift = errors.New("lock file is out of date with package.json")
 
-	if _, err := ctx.LoadArtifactCache(artifactKeys, nodeModules); err != nil {
-		ctx.L().Error("Error restoring cache artifacts", zap.Error(err))
+// lockFileDriftMarkers are messages printed by package managers refusing to
+// perform a frozen install due to an outdated lockfile.
+var lockFileDriftMarkers = []string{
+	"can only install packages when your package.json and package-lock.json",
+	"ERR_PNPM_OUTDATED_LOCKFILE",
+	"Your lockfile needs to be updated",
+	"YN0028",
+	"lockfile had changes, but lockfile is frozen",
+}
+
+var errLintOnlyFrozen = fmt.Errorf("%s=lint-only cannot be combined with %s=frozen", envInstallScope, envInstallMode)
+
+// installSettings holds the settings controlling how dependencies are
+// installed.
+type installSettings struct {
+	mode          installMode
+	scope         installScope
+	ignoreScripts bool
+}
+
+func loadInstallSettings(ctx cocov.Context) (installSettings, error) {
+	var s installSettings
+	var err error
+
+	if s.mode, err = dependencyInstallMode(); err != nil {
+		ctx.L().Error("invalid install mode", zap.Error(err))
+		return s, err
+	}
+
+	if s.scope, err = dependencyInstallScope(); err != nil {
+		ctx.L().Error("invalid install scope", zap.Error(err))
+		return s, err
+	}
+
+	if s.ignoreScripts, err = ignoreScripts(); err != nil {
+		ctx.L().Error("invalid ignore scripts setting", zap.Error(err))
+		return s, err
+	}
+
+	// Lint-only installs change package.json, which frozen installs
+	// refuse to do.
+	if s.mode == modeFrozen && s.scope == scopeLintOnly {
+		ctx.L().Error(errLintOnlyFrozen.Error())
+		return s, errLintOnlyFrozen
+	}
+
+	return s, nil
+}
+
+func restoreNodeModules(ctx cocov.Context, e Exec, manager, file, nodePath, repoPath string) error {
+	settings, err := loadInstallSettings(ctx)
+	if err != nil {
 		return err
 	}
 
+	if settings.mode == modeFrozen && file == "" {
+		ctx.L().Error(errFrozenNoLockFile.Error(), zap.String("path", repoPath))
+		return errFrozenNoLockFile
+	}
+
 	envs := map[string]string{"PATH": nodePath}
 	opts := &cocov.ExecOpts{Workdir: repoPath, Env: envs}
 
-	ctx.L().Info("Restoring node modules", zap.String("package manager", manager))
-	stdOut, stdErr, err := e.Exec2(manager, []string{"install"}, opts)
+	managerVersion, err := toolVersion(ctx, e, manager, opts)
+	if err != nil {
+		return err
+	}
+
+	args, err := installArgs(manager, managerVersion, settings)
+	if err != nil {
+		ctx.L().Error("failed to determine install arguments", zap.Error(err))
+		return err
+	}
+
+	nodeModules := cacheDirectory(manager, repoPath)
+	artifactKeys, err := nodeModulesCacheKeys(ctx, e, manager, managerVersion, file, repoPath, settings, opts)
+	if err != nil {
+		return err
+	}
+
+	hit, err := ctx.LoadArtifactCache(artifactKeys, nodeModules)
+	if err != nil {
+		ctx.L().Error("Error restoring cache artifacts", zap.Error(err))
+		return err
+	}
+
+	// The pnpm store is cached on its own. It is stored again whenever it
+	// misses, and whenever node_modules misses, as dependencies fetched for
+	// a new lockfile were added to it.
+	var storePath string
+	var storeKeys []string
+	storeHit := true
+	if manager == pnpm {
+		if storePath, storeKeys, err = pnpmStore(ctx, e, managerVersion, opts); err != nil {
+			return err
+		}
+
+		if storeHit, err = ctx.LoadArtifactCache(storeKeys, storePath); err != nil {
+			ctx.L().Error("Error restoring pnpm store", zap.Error(err))
+			return err
+		}
+	}
+
+	if err = installDependencies(ctx, e, manager, file, repoPath, args, settings, opts); err != nil {
+		return err
+	}
+
+	if hit {
+		ctx.L().Info("node modules restored from cache, skipping store")
+	} else if err = ctx.StoreArtifactCache(artifactKeys, nodeModules); err != nil {
+		ctx.L().Error("Error storing cache artifact", zap.Error(err))
+		return err
+	}
+
+	if storePath != "" && (!hit || !storeHit) {
+		if err = ctx.StoreArtifactCache(storeKeys, storePath); err != nil {
+			ctx.L().Error("Error storing pnpm store", zap.Error(err))
+			return err
+		}
+	}
+
+	return nil
+}
+
+func installDependencies(ctx cocov.Context, e Exec, manager, file, repoPath string, args []string, settings installSettings, opts *cocov.ExecOpts) (err error) {
+	if settings.scope == scopeLintOnly {
+		var restore func() error
+		if restore, err = trimToLintDependencies(ctx, repoPath); err != nil {
+			return err
+		}
+
+		defer func() {
+			if rErr := restore(); rErr != nil {
+				ctx.L().Error("failed to restore package.json", zap.Error(rErr))
+				if err == nil {
+					err = rErr
+				}
+			}
+		}()
+	}
+
+	ctx.L().Info("Restoring node modules",
+		zap.String("package manager", manager),
+		zap.Strings("args", args),
+	)
+	stdOut, stdErr, err := e.Exec2(manager, args, opts)
 	if err != nil {
 		ctx.L().Error("error restoring node modules",
 			zap.String("std out", string(stdOut)),
 			zap.String("std err", string(stdErr)),
 			zap.Error(err),
 		)
-		return err
-	}
 
-	if err = ctx.StoreArtifactCache(artifactKeys, nodeModules); err != nil {
-		ctx.L().Error("Error storing cache artifact", zap.Error(err))
+		if settings.mode == modeFrozen && hasLockFileDrift(stdOut, stdErr) {
+			return fmt.Errorf("%w: update %s by running %s install", errLockFileDrift, file, manager)
+		}
 		return err
 	}
 
 	return nil
 }
+
+// installArgs returns the arguments used to install dependencies through
+// manager. Frozen installs and skipping lifecycle scripts are expressed
+// differently by yarn 2 and newer. Lint-only installs explicitly allow
+// lockfile updates, as pnpm and yarn 2 and newer forbid them by default on
+// CI environments.
+func installArgs(manager, managerVersion string, settings installSettings) ([]string, error) {
+	args := []string{"install"}
+	frozen, skipScripts := "--frozen-lockfile", "--ignore-scripts"
+	unfrozen := ""
+
+	switch manager {
+	case npm:
+		if settings.mode == modeFrozen {
+			args = []string{"ci"}
+		}
+
+	case pnpm:
+		unfrozen = "--no-frozen-lockfile"
+
+	case yarn:
+		major, _, _ := strings.Cut(managerVersion, ".")
+		n, err := strconv.Atoi(major)
+		if err != nil {
+			return nil, fmt.Errorf("invalid yarn version %q: %w", managerVersion, err)
+		}
+
+		if n > 1 {
+			frozen, skipScripts = "--immutable", "--mode=skip-build"
+			unfrozen = "--no-immutable"
+		}
+	}
+
+	switch {
+	case settings.mode == modeFrozen && manager != npm:
+		args = append(args, frozen)
+	case settings.scope == scopeLintOnly && unfrozen != "":
+		args = append(args, unfrozen)
+	}
+
+	if settings.ignoreScripts {
+		args = append(args, skipScripts)
+	}
+
+	return args, nil
+}
+
+func hasLockFileDrift(outputs ...[]byte) bool {
+	for _, out := range outputs {
+		for _, marker := range lockFileDriftMarkers {
+			if strings.Contains(string(out), marker) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// cacheDirectory returns the directory holding installed dependencies, which
+// is kept in the artifact cache. Projects using yarn Plug'n'Play have no
+// node_modules directory, and have their zip cache stored instead.
+func cacheDirectory(manager, repoPath string) string {
+	if manager == yarn && usesPnP(repoPath) {
+		return filepath.Join(repoPath, ".yarn", "cache")
+	}
+
+	return filepath.Join(repoPath, "node_modules")
+}
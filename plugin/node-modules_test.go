@@ -1,28 +1,61 @@
 package plugin
 
 import (
+	"crypto/sha256"
 	"errors"
+	"fmt"
+	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/cocov-ci/go-plugin-kit/cocov"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+// restoreFixture creates a project with a package.json and the provided
+// lockfile, returning its path and the artifact cache keys of its
+// dependencies.
+func restoreFixture(t *testing.T, manager, managerVersion, lockFile string) (string, []string) {
+	wd := t.TempDir()
+	pkg := []byte(`{"devDependencies": {"eslint": "^8.0.0"}}`)
+	require.NoError(t, os.WriteFile(filepath.Join(wd, pkgJson), pkg, os.ModePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(wd, lockFile), nil, os.ModePerm))
+
+	platform, err := detectPlatform()
+	require.NoError(t, err)
+
+	return wd, []string{
+		"node_modules",
+		"platform=" + platform,
+		"node=v18.16.0",
+		manager + "=" + managerVersion,
+		"package.json=" + fmt.Sprintf("%x", sha256.Sum256(pkg)),
+		lockFile + "=" + fmt.Sprintf("%x", sha256.Sum256(nil)),
+	}
+}
+
+func expectVersions(helper *testHelper, manager, managerVersion string, opts *cocov.ExecOpts) {
+	helper.exec.EXPECT().
+		Exec2(manager, []string{"--version"}, opts).
+		Return([]byte(managerVersion+"\n"), nil, nil)
+	helper.exec.EXPECT().
+		Exec2("node", []string{"--version"}, opts).
+		Return([]byte("v18.16.0\n"), nil, nil)
+}
+
 func TestRestoreNodeModules(t *testing.T) {
-	wd := "workdir"
 	np := "node-path"
-	nodeModules := filepath.Join(wd, "node_modules")
-	repoJsonFile := filepath.Join(wd, pkgJson)
+	manager := yarn
 	lockFile := "yarn.lock"
-	lockFilePath := filepath.Join(wd, lockFile)
 
-	artifactKeys := []string{repoJsonFile, lockFilePath}
-	manager := yarn
-	opts := &cocov.ExecOpts{Workdir: wd, Env: map[string]string{"PATH": np}}
 	t.Run("Fails to restore node modules", func(t *testing.T) {
 		helper := newTestHelper(t)
+		wd, artifactKeys := restoreFixture(t, manager, "1.22.19", lockFile)
+		opts := &cocov.ExecOpts{Workdir: wd, Env: map[string]string{"PATH": np}}
+		nodeModules := filepath.Join(wd, "node_modules")
 
+		expectVersions(helper, manager, "1.22.19", opts)
 		helper.ctx.EXPECT().LoadArtifactCache(artifactKeys, nodeModules)
 
 		stdOut := []byte("something on std out")
@@ -38,7 +71,11 @@ func TestRestoreNodeModules(t *testing.T) {
 
 	t.Run("Works as expected", func(t *testing.T) {
 		helper := newTestHelper(t)
+		wd, artifactKeys := restoreFixture(t, manager, "1.22.19", lockFile)
+		opts := &cocov.ExecOpts{Workdir: wd, Env: map[string]string{"PATH": np}}
+		nodeModules := filepath.Join(wd, "node_modules")
 
+		expectVersions(helper, manager, "1.22.19", opts)
 		helper.ctx.EXPECT().LoadArtifactCache(artifactKeys, nodeModules)
 
 		helper.exec.EXPECT().
@@ -50,4 +87,236 @@ func TestRestoreNodeModules(t *testing.T) {
 		err := restoreNodeModules(helper.ctx, helper.exec, manager, lockFile, np, wd)
 		require.NoError(t, err)
 	})
+
+	t.Run("Skips storing on cache hits", func(t *testing.T) {
+		helper := newTestHelper(t)
+		wd, artifactKeys := restoreFixture(t, manager, "1.22.19", lockFile)
+		opts := &cocov.ExecOpts{Workdir: wd, Env: map[string]string{"PATH": np}}
+		nodeModules := filepath.Join(wd, "node_modules")
+
+		expectVersions(helper, manager, "1.22.19", opts)
+		helper.ctx.EXPECT().LoadArtifactCache(artifactKeys, nodeModules).Return(true, nil)
+
+		helper.exec.EXPECT().
+			Exec2(manager, []string{"install"}, opts).
+			Return(nil, nil, nil)
+
+		err := restoreNodeModules(helper.ctx, helper.exec, manager, lockFile, np, wd)
+		require.NoError(t, err)
+	})
+
+	t.Run("Caches the pnpm store", func(t *testing.T) {
+		helper := newTestHelper(t)
+		wd, artifactKeys := restoreFixture(t, pnpm, "8.6.0", "pnpm-lock.yaml")
+		opts := &cocov.ExecOpts{Workdir: wd, Env: map[string]string{"PATH": np}}
+		nodeModules := filepath.Join(wd, "node_modules")
+		storePath := "/root/.local/share/pnpm/store/v3"
+		storeKeys := []string{"pnpm-store", artifactKeys[1], "pnpm=8.6.0"}
+
+		expectVersions(helper, pnpm, "8.6.0", opts)
+		helper.ctx.EXPECT().LoadArtifactCache(artifactKeys, nodeModules)
+		helper.exec.EXPECT().
+			Exec2(pnpm, []string{"store", "path"}, opts).
+			Return([]byte(storePath+"\n"), nil, nil)
+		helper.ctx.EXPECT().LoadArtifactCache(storeKeys, storePath)
+
+		helper.exec.EXPECT().
+			Exec2(pnpm, []string{"install"}, opts).
+			Return(nil, nil, nil)
+
+		helper.ctx.EXPECT().StoreArtifactCache(artifactKeys, nodeModules)
+		helper.ctx.EXPECT().StoreArtifactCache(storeKeys, storePath)
+
+		err := restoreNodeModules(helper.ctx, helper.exec, pnpm, "pnpm-lock.yaml", np, wd)
+		require.NoError(t, err)
+	})
+
+	t.Run("Stores a restored pnpm store when node modules miss", func(t *testing.T) {
+		helper := newTestHelper(t)
+		wd, artifactKeys := restoreFixture(t, pnpm, "8.6.0", "pnpm-lock.yaml")
+		opts := &cocov.ExecOpts{Workdir: wd, Env: map[string]string{"PATH": np}}
+		nodeModules := filepath.Join(wd, "node_modules")
+		storePath := "/root/.local/share/pnpm/store/v3"
+		storeKeys := []string{"pnpm-store", artifactKeys[1], "pnpm=8.6.0"}
+
+		expectVersions(helper, pnpm, "8.6.0", opts)
+		helper.ctx.EXPECT().LoadArtifactCache(artifactKeys, nodeModules)
+		helper.exec.EXPECT().
+			Exec2(pnpm, []string{"store", "path"}, opts).
+			Return([]byte(storePath+"\n"), nil, nil)
+		helper.ctx.EXPECT().LoadArtifactCache(storeKeys, storePath).Return(true, nil)
+
+		helper.exec.EXPECT().
+			Exec2(pnpm, []string{"install"}, opts).
+			Return(nil, nil, nil)
+
+		helper.ctx.EXPECT().StoreArtifactCache(artifactKeys, nodeModules)
+		helper.ctx.EXPECT().StoreArtifactCache(storeKeys, storePath)
+
+		err := restoreNodeModules(helper.ctx, helper.exec, pnpm, "pnpm-lock.yaml", np, wd)
+		require.NoError(t, err)
+	})
+
+	for _, storeHit := range []bool{false, true} {
+		name := "Fills a missing pnpm store on node modules hits"
+		if storeHit {
+			name = "Skips stores when both caches hit"
+		}
+
+		t.Run(name, func(t *testing.T) {
+			helper := newTestHelper(t)
+			wd, artifactKeys := restoreFixture(t, pnpm, "8.6.0", "pnpm-lock.yaml")
+			opts := &cocov.ExecOpts{Workdir: wd, Env: map[string]string{"PATH": np}}
+			nodeModules := filepath.Join(wd, "node_modules")
+			storePath := "/root/.local/share/pnpm/store/v3"
+			storeKeys := []string{"pnpm-store", artifactKeys[1], "pnpm=8.6.0"}
+
+			expectVersions(helper, pnpm, "8.6.0", opts)
+			helper.ctx.EXPECT().LoadArtifactCache(artifactKeys, nodeModules).Return(true, nil)
+			helper.exec.EXPECT().
+				Exec2(pnpm, []string{"store", "path"}, opts).
+				Return([]byte(storePath+"\n"), nil, nil)
+			helper.ctx.EXPECT().LoadArtifactCache(storeKeys, storePath).Return(storeHit, nil)
+
+			helper.exec.EXPECT().
+				Exec2(pnpm, []string{"install"}, opts).
+				Return(nil, nil, nil)
+
+			if !storeHit {
+				helper.ctx.EXPECT().StoreArtifactCache(storeKeys, storePath)
+			}
+
+			err := restoreNodeModules(helper.ctx, helper.exec, pnpm, "pnpm-lock.yaml", np, wd)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestRestoreNodeModulesFrozen(t *testing.T) {
+	np := "node-path"
+	lockFile := "package-lock.json"
+
+	t.Run("Requires a lock file", func(t *testing.T) {
+		t.Setenv(envInstallMode, "frozen")
+		helper := newTestHelper(t)
+
+		err := restoreNodeModules(helper.ctx, helper.exec, npm, "", np, t.TempDir())
+		assert.ErrorIs(t, err, errFrozenNoLockFile)
+	})
+
+	t.Run("Runs npm ci", func(t *testing.T) {
+		t.Setenv(envInstallMode, "frozen")
+		t.Setenv(envIgnoreScripts, "true")
+		helper := newTestHelper(t)
+		wd, artifactKeys := restoreFixture(t, npm, "9.6.7", lockFile)
+		opts := &cocov.ExecOpts{Workdir: wd, Env: map[string]string{"PATH": np}}
+		nodeModules := filepath.Join(wd, "node_modules")
+		artifactKeys = append(artifactKeys[:4:4], append([]string{"ignore-scripts"}, artifactKeys[4:]...)...)
+
+		expectVersions(helper, npm, "9.6.7", opts)
+		helper.ctx.EXPECT().LoadArtifactCache(artifactKeys, nodeModules)
+		helper.exec.EXPECT().
+			Exec2(npm, []string{"ci", "--ignore-scripts"}, opts).
+			Return(nil, nil, nil)
+		helper.ctx.EXPECT().StoreArtifactCache(artifactKeys, nodeModules)
+
+		err := restoreNodeModules(helper.ctx, helper.exec, npm, lockFile, np, wd)
+		require.NoError(t, err)
+	})
+
+	t.Run("Reports lock file drift", func(t *testing.T) {
+		t.Setenv(envInstallMode, "frozen")
+		helper := newTestHelper(t)
+		wd, artifactKeys := restoreFixture(t, npm, "9.6.7", lockFile)
+		opts := &cocov.ExecOpts{Workdir: wd, Env: map[string]string{"PATH": np}}
+		nodeModules := filepath.Join(wd, "node_modules")
+
+		stdErr := []byte("npm ERR! `npm ci` can only install packages when your package.json and package-lock.json or npm-shrinkwrap.json are in sync.")
+		expectVersions(helper, npm, "9.6.7", opts)
+		helper.ctx.EXPECT().LoadArtifactCache(artifactKeys, nodeModules)
+		helper.exec.EXPECT().
+			Exec2(npm, []string{"ci"}, opts).
+			Return(nil, stdErr, errors.New("exit status 1"))
+
+		err := restoreNodeModules(helper.ctx, helper.exec, npm, lockFile, np, wd)
+		assert.ErrorIs(t, err, errLockFileDrift)
+	})
+
+	t.Run("Rejects lint-only scope", func(t *testing.T) {
+		t.Setenv(envInstallMode, "frozen")
+		t.Setenv(envInstallScope, "lint-only")
+		helper := newTestHelper(t)
+
+		err := restoreNodeModules(helper.ctx, helper.exec, npm, lockFile, np, t.TempDir())
+		assert.ErrorIs(t, err, errLintOnlyFrozen)
+	})
+
+	t.Run("Rejects unknown modes", func(t *testing.T) {
+		t.Setenv(envInstallMode, "fast")
+		helper := newTestHelper(t)
+
+		err := restoreNodeModules(helper.ctx, helper.exec, npm, lockFile, np, t.TempDir())
+		assert.ErrorContains(t, err, envInstallMode)
+	})
+}
+
+func TestInstallArgs(t *testing.T) {
+	tests := []struct {
+		name      string
+		manager   string
+		version   string
+		mode      installMode
+		scope     installScope
+		noScripts bool
+		expected  []string
+	}{
+		{name: "npm", manager: npm, mode: modeInstall, expected: []string{"install"}},
+		{name: "npm frozen", manager: npm, mode: modeFrozen, expected: []string{"ci"}},
+		{name: "pnpm frozen", manager: pnpm, mode: modeFrozen, noScripts: true, expected: []string{"install", "--frozen-lockfile", "--ignore-scripts"}},
+		{name: "bun frozen", manager: bun, mode: modeFrozen, expected: []string{"install", "--frozen-lockfile"}},
+		{name: "yarn", manager: yarn, version: "1.22.19", mode: modeInstall, expected: []string{"install"}},
+		{name: "yarn classic frozen", manager: yarn, version: "1.22.19", mode: modeFrozen, noScripts: true, expected: []string{"install", "--frozen-lockfile", "--ignore-scripts"}},
+		{name: "yarn berry frozen", manager: yarn, version: "3.6.1", mode: modeFrozen, noScripts: true, expected: []string{"install", "--immutable", "--mode=skip-build"}},
+		{name: "pnpm lint-only", manager: pnpm, scope: scopeLintOnly, expected: []string{"install", "--no-frozen-lockfile"}},
+		{name: "yarn berry lint-only", manager: yarn, version: "4.0.2", scope: scopeLintOnly, expected: []string{"install", "--no-immutable"}},
+		{name: "npm lint-only", manager: npm, scope: scopeLintOnly, expected: []string{"install"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := installSettings{mode: tt.mode, scope: tt.scope, ignoreScripts: tt.noScripts}
+			args, err := installArgs(tt.manager, tt.version, settings)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, args)
+		})
+	}
+
+	t.Run("Rejects invalid yarn versions", func(t *testing.T) {
+		_, err := installArgs(yarn, "unknown", installSettings{})
+		assert.Error(t, err)
+	})
+}
+
+func TestCacheDirectory(t *testing.T) {
+	t.Run("Uses node_modules", func(t *testing.T) {
+		dir := t.TempDir()
+		assert.Equal(t, filepath.Join(dir, "node_modules"), cacheDirectory(npm, dir))
+		assert.Equal(t, filepath.Join(dir, "node_modules"), cacheDirectory(yarn, dir))
+	})
+
+	t.Run("Uses the yarn cache with Plug'n'Play", func(t *testing.T) {
+		dir := t.TempDir()
+		err := os.WriteFile(filepath.Join(dir, yarnrcFile), []byte("yarnPath: .yarn/releases/yarn-3.6.1.cjs\n"), os.ModePerm)
+		require.NoError(t, err)
+
+		assert.Equal(t, filepath.Join(dir, ".yarn", "cache"), cacheDirectory(yarn, dir))
+	})
+
+	t.Run("Uses node_modules with the node-modules linker", func(t *testing.T) {
+		dir := t.TempDir()
+		err := os.WriteFile(filepath.Join(dir, yarnrcFile), []byte("nodeLinker: node-modules\n"), os.ModePerm)
+		require.NoError(t, err)
+
+		assert.Equal(t, filepath.Join(dir, "node_modules"), cacheDirectory(yarn, dir))
+	})
 }
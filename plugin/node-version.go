@@ -0,0 +1,162 @@
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/Masterminds/semver"
+	"github.com/cocov-ci/go-plugin-kit/cocov"
+	"go.uber.org/zap"
+)
+
+const (
+	nvmrcFile       = ".nvmrc"
+	nodeVersionFile = ".node-version"
+	voltaSource     = "package.json volta.node"
+	enginesSource   = "package.json engines.node"
+)
+
+// findNodeVersion looks for the Node version required by the package at
+// repoPath. Sources are checked in the following order of precedence:
+// .nvmrc, .node-version, volta.node and engines.node. It returns the raw
+// version along with the name of the source it was read from, or empty
+// strings in case none of them is present.
+func findNodeVersion(repoPath string, pkg *packageJson) (string, string, error) {
+	for _, name := range []string{nvmrcFile, nodeVersionFile} {
+		v, err := readVersionFile(filepath.Join(repoPath, name))
+		if err != nil {
+			return "", "", err
+		}
+
+		if v != "" {
+			return v, name, nil
+		}
+	}
+
+	if v := strings.TrimSpace(pkg.Volta.Node); v != "" {
+		return v, voltaSource, nil
+	}
+
+	if v := strings.TrimSpace(pkg.Engines.Node); v != "" {
+		return v, enginesSource, nil
+	}
+
+	return "", "", nil
+}
+
+// readVersionFile returns the first non-empty, non-comment line of a version
+// file such as .nvmrc. A missing file yields an empty version.
+func readVersionFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+
+		if line = strings.TrimSpace(line); line != "" {
+			return line, nil
+		}
+	}
+
+	return "", nil
+}
+
+var latestAliases = map[string]bool{
+	"node":    true,
+	"stable":  true,
+	"latest":  true,
+	"current": true,
+}
+
+// isNodeAlias reports whether version is an nvm alias such as "lts/*" or
+// "node" instead of a semver range.
+func isNodeAlias(version string) bool {
+	version = strings.ToLower(version)
+	return latestAliases[version] || version == "lts" || strings.HasPrefix(version, "lts/")
+}
+
+// resolveNodeAlias resolves an nvm alias against the provided index. Besides
+// the aliases for the latest release, "lts/*" resolves to the latest LTS
+// release, "lts/<codename>" to the latest release of the given LTS line, and
+// "lts/-n" to the latest release of the n-th LTS line before the current one.
+func resolveNodeAlias(ctx cocov.Context, alias string, index *versionIndex) (*semver.Version, error) {
+	name := strings.ToLower(alias)
+
+	var match func(info versionInfo) bool
+	switch {
+	case latestAliases[name]:
+		match = func(versionInfo) bool { return true }
+
+	case name == "lts" || name == "lts/*":
+		match = func(info versionInfo) bool { return info.LTS != "" }
+
+	case strings.HasPrefix(name, "lts/-"):
+		n, err := strconv.Atoi(strings.TrimPrefix(name, "lts/-"))
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid node version alias %s", alias)
+		}
+
+		lines := ltsLines(index)
+		if n >= len(lines) {
+			return nil, fmt.Errorf("no compatible versions found for %s", alias)
+		}
+
+		codename := lines[n]
+		match = func(info versionInfo) bool { return info.LTS == codename }
+
+	default:
+		codename := strings.TrimPrefix(name, "lts/")
+		match = func(info versionInfo) bool {
+			return strings.EqualFold(string(info.LTS), codename)
+		}
+	}
+
+	for _, info := range index.versions {
+		if !match(info) {
+			continue
+		}
+
+		v, err := semver.NewVersion(info.Version)
+		if err != nil {
+			ctx.L().Error("failed to build semver version using node index",
+				zap.String("index data", info.Version),
+				zap.Error(err),
+			)
+			return nil, err
+		}
+
+		return v, nil
+	}
+
+	return nil, fmt.Errorf("no compatible versions found for %s", alias)
+}
+
+// ltsLines returns the codenames of all LTS lines present in the index, in
+// the order they first appear.
+func ltsLines(index *versionIndex) []ltsName {
+	var lines []ltsName
+	seen := map[ltsName]bool{}
+	for _, info := range index.versions {
+		if info.LTS == "" || seen[info.LTS] {
+			continue
+		}
+
+		seen[info.LTS] = true
+		lines = append(lines, info.LTS)
+	}
+
+	return lines
+}
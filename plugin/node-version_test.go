@@ -0,0 +1,110 @@
+package plugin
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindNodeVersion(t *testing.T) {
+	pkg := &packageJson{}
+	pkg.Volta.Node = "18.16.0"
+	pkg.Engines.Node = ">=16"
+
+	t.Run("Prefers .nvmrc", func(t *testing.T) {
+		dir := t.TempDir()
+		err := os.WriteFile(filepath.Join(dir, nvmrcFile), []byte("# pinned\nlts/hydrogen\n"), os.ModePerm)
+		require.NoError(t, err)
+		err = os.WriteFile(filepath.Join(dir, nodeVersionFile), []byte("20\n"), os.ModePerm)
+		require.NoError(t, err)
+
+		version, source, err := findNodeVersion(dir, pkg)
+		require.NoError(t, err)
+		assert.Equal(t, "lts/hydrogen", version)
+		assert.Equal(t, nvmrcFile, source)
+	})
+
+	t.Run("Uses .node-version", func(t *testing.T) {
+		dir := t.TempDir()
+		err := os.WriteFile(filepath.Join(dir, nodeVersionFile), []byte("v20.1.0\n"), os.ModePerm)
+		require.NoError(t, err)
+
+		version, source, err := findNodeVersion(dir, pkg)
+		require.NoError(t, err)
+		assert.Equal(t, "v20.1.0", version)
+		assert.Equal(t, nodeVersionFile, source)
+	})
+
+	t.Run("Uses volta before engines", func(t *testing.T) {
+		version, source, err := findNodeVersion(t.TempDir(), pkg)
+		require.NoError(t, err)
+		assert.Equal(t, "18.16.0", version)
+		assert.Equal(t, voltaSource, source)
+	})
+
+	t.Run("Uses engines", func(t *testing.T) {
+		p := &packageJson{}
+		p.Engines.Node = ">=16"
+
+		version, source, err := findNodeVersion(t.TempDir(), p)
+		require.NoError(t, err)
+		assert.Equal(t, ">=16", version)
+		assert.Equal(t, enginesSource, source)
+	})
+
+	t.Run("Returns nothing without sources", func(t *testing.T) {
+		version, source, err := findNodeVersion(t.TempDir(), &packageJson{})
+		require.NoError(t, err)
+		assert.Empty(t, version)
+		assert.Empty(t, source)
+	})
+}
+
+func TestResolveNodeAlias(t *testing.T) {
+	vi := &versionIndex{
+		versions: []versionInfo{
+			{Version: "v20.1.0"},
+			{Version: "v18.16.0", LTS: "Hydrogen"},
+			{Version: "v18.15.0", LTS: "Hydrogen"},
+			{Version: "v16.20.0", LTS: "Gallium"},
+		},
+	}
+
+	tests := map[string]string{
+		"node":         "20.1.0",
+		"lts/*":        "18.16.0",
+		"lts/hydrogen": "18.16.0",
+		"lts/Gallium":  "16.20.0",
+		"lts/-1":       "16.20.0",
+	}
+
+	for alias, expected := range tests {
+		t.Run(alias, func(t *testing.T) {
+			helper := newTestHelper(t)
+
+			require.True(t, isNodeAlias(alias))
+			v, err := resolveNodeAlias(helper.ctx, alias, vi)
+			require.NoError(t, err)
+			assert.Equal(t, expected, v.String())
+		})
+	}
+
+	t.Run("Unknown LTS line", func(t *testing.T) {
+		helper := newTestHelper(t)
+
+		_, err := resolveNodeAlias(helper.ctx, "lts/argon", vi)
+		assert.Error(t, err)
+	})
+
+	t.Run("Decodes LTS names from the index", func(t *testing.T) {
+		var infos []versionInfo
+		data := []byte(`[{"version":"v20.1.0","lts":false},{"version":"v18.16.0","lts":"Hydrogen"}]`)
+		require.NoError(t, json.Unmarshal(data, &infos))
+		assert.Equal(t, ltsName(""), infos[0].LTS)
+		assert.Equal(t, ltsName("Hydrogen"), infos[1].LTS)
+	})
+}
@@ -2,137 +2,474 @@ package plugin
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
-	"unicode"
-
-	"github.com/Masterminds/semver"
-)
-
-const (
-	stateFeed = iota
-	stateOr
 )
 
+// parser parses version ranges following the grammar implemented by
+// node-semver, as documented at https://github.com/npm/node-semver#ranges.
+// Besides whitespace, commas are also accepted as a separator between
+// comparators.
 type parser struct {
-	state       int
-	constraints []string
-	buffer      []byte
+	input string
 }
 
 func newParser() *parser {
-	return &parser{constraints: []string{}, buffer: []byte{}, state: stateFeed}
+	return &parser{}
+}
+
+// rangeError describes an invalid range, pointing to the 1-based column in
+// which the problem was found.
+type rangeError struct {
+	input  string
+	column int
+	reason string
+}
+
+func (e *rangeError) Error() string {
+	return fmt.Sprintf("error parsing constraint \"%s\": %s at column %d",
+		e.input, e.reason, e.column)
+}
+
+func (p *parser) errorAt(offset int, format string, args ...any) error {
+	return &rangeError{
+		input:  p.input,
+		column: offset + 1,
+		reason: fmt.Sprintf(format, args...),
+	}
 }
 
 func (p *parser) parse(s string) (constraints, error) {
-	for _, b := range s {
-		p.feed(byte(b))
+	p.input = s
+
+	var cs constraints
+	start := 0
+	for {
+		end := len(s)
+		idx := strings.Index(s[start:], "||")
+		if idx >= 0 {
+			end = start + idx
+		}
+
+		set, err := p.parseRange(start, end)
+		if err != nil {
+			return nil, err
+		}
+		cs = append(cs, set)
+
+		if idx < 0 {
+			return cs, nil
+		}
+		start = end + 2
 	}
+}
+
+// word is a single comparator of a range, such as ">= 1.2.3". Offsets are
+// relative to the beginning of the input.
+type word struct {
+	op         string
+	opOffset   int
+	text       string
+	textOffset int
+}
+
+func isRangeSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r' || b == ','
+}
+
+func isOperator(b byte) bool {
+	return b == '<' || b == '>' || b == '=' || b == '~' || b == '^'
+}
+
+// split breaks the range between start and end into words. Operators may be
+// separated from their versions by whitespace.
+func (p *parser) split(start, end int) ([]word, error) {
+	var words []word
+	s := p.input
+	i := start
+	for {
+		for i < end && isRangeSpace(s[i]) {
+			i++
+		}
+
+		if i >= end {
+			return words, nil
+		}
+
+		w := word{opOffset: i}
+		for i < end && isOperator(s[i]) {
+			i++
+		}
+		w.op = s[w.opOffset:i]
+
+		if w.op != "" {
+			for i < end && isRangeSpace(s[i]) {
+				i++
+			}
+		}
+
+		w.textOffset = i
+		for i < end && !isRangeSpace(s[i]) {
+			i++
+		}
+		w.text = s[w.textOffset:i]
 
-	p.finish()
+		if w.text == "" {
+			return nil, p.errorAt(w.opOffset, "missing version after operator %q", w.op)
+		}
 
-	return p.render()
+		words = append(words, w)
+	}
 }
 
-func (p *parser) render() (constraints, error) {
-	cs := make(constraints, 0, len(p.constraints))
-	for _, s := range p.constraints {
-		s = replaceXVersion(s)
-		c, err := semver.NewConstraint(s)
+func (p *parser) parseRange(start, end int) (comparatorSet, error) {
+	words, err := p.split(start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(words) == 0 {
+		return comparatorSet{{any: true}}, nil
+	}
+
+	if len(words) == 3 && words[1].op == "" && words[1].text == "-" {
+		return p.parseHyphen(words[0], words[2])
+	}
+
+	set := comparatorSet{}
+	for _, w := range words {
+		cs, err := p.parseComparator(w)
 		if err != nil {
-			return nil, constraintRenderErr(s, err)
+			return nil, err
 		}
-		cs = append(cs, c)
+		set = append(set, cs...)
 	}
-	return cs, nil
+
+	return set, nil
 }
 
-var symbols = map[rune]bool{
-	'=': true,
-	'!': true,
-	'>': true,
-	'<': true,
-	'^': true,
-	'~': true,
+func (p *parser) parseHyphen(from, to word) (comparatorSet, error) {
+	for _, w := range []word{from, to} {
+		if w.op != "" {
+			return nil, p.errorAt(w.opOffset, "unexpected operator %q in hyphen range", w.op)
+		}
+	}
+
+	f, err := p.parsePartial(from)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := p.parsePartial(to)
+	if err != nil {
+		return nil, err
+	}
+
+	set := comparatorSet{}
+	switch {
+	case f.isX(0):
+	case f.isX(1):
+		set = append(set, gte(f.major, 0, 0))
+	case f.isX(2):
+		set = append(set, gte(f.major, f.minor, 0))
+	default:
+		set = append(set, gte(f.major, f.minor, f.patch, f.pre...))
+	}
+
+	switch {
+	case t.isX(0):
+	case t.isX(1):
+		set = append(set, lt(t.major+1, 0, 0, "0"))
+	case t.isX(2):
+		set = append(set, lt(t.major, t.minor+1, 0, "0"))
+	default:
+		set = append(set, comparator{op: "<=", version: rangeVersion{t.major, t.minor, t.patch, t.pre}})
+	}
+
+	if len(set) == 0 {
+		return comparatorSet{{any: true}}, nil
+	}
+
+	return set, nil
 }
 
-func isConstraintSym(r rune) bool { return symbols[r] }
+func (p *parser) parseComparator(w word) ([]comparator, error) {
+	switch w.op {
+	case "", "=", "<", "<=", ">", ">=", "~", "~>", "^":
+	default:
+		return nil, p.errorAt(w.opOffset, "invalid operator %q", w.op)
+	}
 
-func symBuffer(buff []byte) bool {
-	for _, b := range buff {
-		if !isConstraintSym(rune(b)) {
-			return false
-		}
+	if w.text == "-" {
+		return nil, p.errorAt(w.textOffset, "unexpected hyphen")
 	}
-	return true
+
+	pt, err := p.parsePartial(w)
+	if err != nil {
+		return nil, err
+	}
+
+	switch w.op {
+	case "~", "~>":
+		return tilde(pt), nil
+	case "^":
+		return caret(pt), nil
+	default:
+		return xRange(w.op, pt), nil
+	}
+}
+
+// partial is a version in which any component may be missing or replaced by
+// a wildcard, both represented by a negative number.
+type partial struct {
+	major, minor, patch int64
+	pre                 []string
+}
+
+func (pt partial) isX(component int) bool {
+	return []int64{pt.major, pt.minor, pt.patch}[component] < 0
 }
 
-func (p *parser) feed(b byte) {
-	r := rune(b)
-	switch p.state {
-	case stateFeed:
-		if unicode.IsSpace(r) {
-			return
+func (p *parser) parsePartial(w word) (partial, error) {
+	s := w.text
+	i := 0
+	for i < len(s) && (s[i] == 'v' || s[i] == '=') {
+		i++
+	}
+
+	components := []int64{-1, -1, -1}
+	for n := 0; n < 3; n++ {
+		v, next, err := p.parseXR(s, i, w.textOffset)
+		if err != nil {
+			return partial{}, err
 		}
+		components[n], i = v, next
 
-		if r == ',' {
-			p.constraints = append(p.constraints, string(p.buffer))
-			p.buffer = p.buffer[:0]
-			return
+		if i < len(s) && s[i] == '.' && n < 2 {
+			i++
+			continue
 		}
 
-		if r == 'v' {
-			if symBuffer(p.buffer) {
-				p.buffer = append(p.buffer, b)
-				return
-			}
+		if i < len(s) && (n < 2 || (s[i] != '-' && s[i] != '+')) {
+			return partial{}, p.errorAt(w.textOffset+i, "unexpected character %q", s[i])
+		}
+		break
+	}
+
+	pt := partial{major: components[0], minor: components[1], patch: components[2]}
+
+	if i < len(s) && s[i] == '-' {
+		ids, next, err := p.parseIdentifiers(s, i+1, w.textOffset, true)
+		if err != nil {
+			return partial{}, err
+		}
+		pt.pre, i = ids, next
+	}
+
+	if i < len(s) && s[i] == '+' {
+		_, next, err := p.parseIdentifiers(s, i+1, w.textOffset, false)
+		if err != nil {
+			return partial{}, err
+		}
+		i = next
+	}
+
+	if i < len(s) {
+		return partial{}, p.errorAt(w.textOffset+i, "unexpected character %q", s[i])
+	}
+
+	return pt, nil
+}
+
+// parseXR parses a version component starting at i, which is either a number
+// or one of the x, X and * wildcards.
+func (p *parser) parseXR(s string, i, offset int) (int64, int, error) {
+	if i < len(s) && (s[i] == 'x' || s[i] == 'X' || s[i] == '*') {
+		return -1, i + 1, nil
+	}
 
-			p.constraints = append(p.constraints, string(p.buffer))
-			p.buffer = p.buffer[:0]
-			return
+	start := i
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+
+	if start == i {
+		if i < len(s) {
+			return 0, i, p.errorAt(offset+i, "unexpected character %q", s[i])
 		}
+		return 0, i, p.errorAt(offset+i, "missing version number")
+	}
 
-		if isConstraintSym(r) {
-			if len(p.buffer) <= 1 {
-				p.buffer = append(p.buffer, b)
-				return
+	if i-start > 1 && s[start] == '0' {
+		return 0, i, p.errorAt(offset+start, "version numbers must not have leading zeros")
+	}
+
+	v, err := strconv.ParseInt(s[start:i], 10, 64)
+	if err != nil {
+		return 0, i, p.errorAt(offset+start, "invalid version number %q", s[start:i])
+	}
+
+	return v, i, nil
+}
+
+// parseIdentifiers parses dot-separated prerelease or build identifiers
+// starting at i, stopping at the first + sign or at the end of s.
+func (p *parser) parseIdentifiers(s string, i, offset int, prerelease bool) ([]string, int, error) {
+	var ids []string
+	for {
+		start := i
+		for i < len(s) && isIdentifierChar(s[i]) {
+			i++
+		}
+
+		id := s[start:i]
+		if id == "" {
+			if i < len(s) {
+				return nil, i, p.errorAt(offset+i, "unexpected character %q", s[i])
 			}
+			return nil, i, p.errorAt(offset+i, "missing identifier")
+		}
 
-			p.constraints = append(p.constraints, string(p.buffer))
-			p.buffer = p.buffer[:0]
-			p.buffer = append(p.buffer, b)
-			return
+		if prerelease && len(id) > 1 && id[0] == '0' && isNumeric(id) {
+			return nil, i, p.errorAt(offset+start, "numeric identifiers must not have leading zeros")
 		}
+		ids = append(ids, id)
 
-		if r == '|' {
-			p.buffer = append(p.buffer, b)
-			p.state = stateOr
-			return
+		if i < len(s) && s[i] == '.' {
+			i++
+			continue
 		}
 
-	case stateOr:
-		if b == ',' {
-			p.constraints = append(p.constraints, string(p.buffer))
-			p.buffer = p.buffer[:0]
-			p.state = stateFeed
-			return
+		return ids, i, nil
+	}
+}
+
+func isIdentifierChar(b byte) bool {
+	return b == '-' ||
+		(b >= '0' && b <= '9') ||
+		(b >= 'a' && b <= 'z') ||
+		(b >= 'A' && b <= 'Z')
+}
+
+func isNumeric(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
 		}
 	}
+	return true
+}
 
-	p.buffer = append(p.buffer, b)
+func gte(major, minor, patch int64, pre ...string) comparator {
+	return comparator{op: ">=", version: rangeVersion{major, minor, patch, pre}}
 }
 
-func (p *parser) finish() {
-	if len(p.buffer) != 0 {
-		p.constraints = append(p.constraints, string(p.buffer))
+func lt(major, minor, patch int64, pre ...string) comparator {
+	return comparator{op: "<", version: rangeVersion{major, minor, patch, pre}}
+}
+
+// tilde desugars ~ ranges, which allow patch-level changes when a minor
+// version is specified, and minor-level changes otherwise.
+func tilde(pt partial) []comparator {
+	switch {
+	case pt.isX(0):
+		return []comparator{{any: true}}
+	case pt.isX(1):
+		return []comparator{gte(pt.major, 0, 0), lt(pt.major+1, 0, 0, "0")}
+	case pt.isX(2):
+		return []comparator{gte(pt.major, pt.minor, 0), lt(pt.major, pt.minor+1, 0, "0")}
 	}
 
-	p.buffer = p.buffer[:0]
+	return []comparator{
+		gte(pt.major, pt.minor, pt.patch, pt.pre...),
+		lt(pt.major, pt.minor+1, 0, "0"),
+	}
 }
 
-func constraintRenderErr(constraint string, e error) error {
-	return fmt.Errorf("error parsing constraint \"%s\": %s", constraint, e)
+// caret desugars ^ ranges, which allow changes that do not modify the
+// left-most non-zero component.
+func caret(pt partial) []comparator {
+	switch {
+	case pt.isX(0):
+		return []comparator{{any: true}}
+	case pt.isX(1):
+		return []comparator{gte(pt.major, 0, 0), lt(pt.major+1, 0, 0, "0")}
+	case pt.isX(2):
+		if pt.major == 0 {
+			return []comparator{gte(0, pt.minor, 0), lt(0, pt.minor+1, 0, "0")}
+		}
+		return []comparator{gte(pt.major, pt.minor, 0), lt(pt.major+1, 0, 0, "0")}
+	}
+
+	lower := gte(pt.major, pt.minor, pt.patch, pt.pre...)
+	switch {
+	case pt.major == 0 && pt.minor == 0:
+		return []comparator{lower, lt(0, 0, pt.patch+1, "0")}
+	case pt.major == 0:
+		return []comparator{lower, lt(0, pt.minor+1, 0, "0")}
+	}
+
+	return []comparator{lower, lt(pt.major+1, 0, 0, "0")}
 }
 
-func replaceXVersion(s string) string {
-	return strings.Replace(s, "x", "*", -1)
+// xRange desugars primitive comparators, whose versions may contain
+// wildcards or missing components.
+func xRange(op string, pt partial) []comparator {
+	xM := pt.isX(0)
+	xm := xM || pt.isX(1)
+	xp := xm || pt.isX(2)
+
+	if op == "=" && xp {
+		op = ""
+	}
+
+	switch {
+	case xM:
+		if op == ">" || op == "<" {
+			// Nothing is allowed
+			return []comparator{lt(0, 0, 0, "0")}
+		}
+		return []comparator{{any: true}}
+
+	case op != "" && xp:
+		major, minor, patch := pt.major, pt.minor, int64(0)
+		if xm {
+			minor = 0
+		}
+
+		switch op {
+		case ">":
+			op = ">="
+			if xm {
+				major, minor = major+1, 0
+			} else {
+				minor++
+			}
+		case "<=":
+			op = "<"
+			if xm {
+				major++
+			} else {
+				minor++
+			}
+		}
+
+		c := comparator{op: op, version: rangeVersion{major, minor, patch, nil}}
+		if op == "<" {
+			c.version.pre = []string{"0"}
+		}
+		return []comparator{c}
+
+	case xm:
+		return []comparator{gte(pt.major, 0, 0), lt(pt.major+1, 0, 0, "0")}
+
+	case xp:
+		return []comparator{gte(pt.major, pt.minor, 0), lt(pt.major, pt.minor+1, 0, "0")}
+	}
+
+	if op == "" {
+		op = "="
+	}
+
+	return []comparator{{op: op, version: rangeVersion{pt.major, pt.minor, pt.patch, pt.pre}}}
 }
@@ -8,59 +8,295 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-func TestParser(t *testing.T) {
-	base := ">= 0.10.3 < 0.12"
-	p := newParser()
+// Cases ported from node-semver's test/fixtures/range-include.js and
+// test/fixtures/range-exclude.js, leaving out those depending on the loose
+// and includePrerelease options.
+var rangeIncludeFixtures = [][2]string{
+	{"1.0.0 - 2.0.0", "1.2.3"},
+	{"^1.2.3+build", "1.2.3"},
+	{"^1.2.3+build", "1.3.0"},
+	{"1.2.3-pre+asdf - 2.4.3-pre+asdf", "1.2.3"},
+	{"1.2.3-pre+asdf - 2.4.3-pre+asdf", "1.2.3-pre.2"},
+	{"1.2.3-pre+asdf - 2.4.3-pre+asdf", "2.4.3-alpha"},
+	{"1.2.3+asdf - 2.4.3+asdf", "1.2.3"},
+	{"1.0.0", "1.0.0"},
+	{">=*", "0.2.4"},
+	{"", "1.0.0"},
+	{"*", "1.2.3"},
+	{">=1.0.0", "1.0.0"},
+	{">=1.0.0", "1.0.1"},
+	{">=1.0.0", "1.1.0"},
+	{">1.0.0", "1.0.1"},
+	{">1.0.0", "1.1.0"},
+	{"<=2.0.0", "2.0.0"},
+	{"<=2.0.0", "1.9999.9999"},
+	{"<=2.0.0", "0.2.9"},
+	{"<2.0.0", "1.9999.9999"},
+	{"<2.0.0", "0.2.9"},
+	{">= 1.0.0", "1.0.0"},
+	{">=  1.0.0", "1.0.1"},
+	{">=   1.0.0", "1.1.0"},
+	{"> 1.0.0", "1.0.1"},
+	{">  1.0.0", "1.1.0"},
+	{"<=   2.0.0", "2.0.0"},
+	{"<= 2.0.0", "1.9999.9999"},
+	{"<=  2.0.0", "0.2.9"},
+	{"<    2.0.0", "1.9999.9999"},
+	{"<\t2.0.0", "0.2.9"},
+	{">=0.1.97", "0.1.97"},
+	{"0.1.20 || 1.2.4", "1.2.4"},
+	{">=0.2.3 || <0.0.1", "0.0.0"},
+	{">=0.2.3 || <0.0.1", "0.2.3"},
+	{">=0.2.3 || <0.0.1", "0.2.4"},
+	{"||", "1.3.4"},
+	{"2.x.x", "2.1.3"},
+	{"1.2.x", "1.2.3"},
+	{"1.2.x || 2.x", "2.1.3"},
+	{"1.2.x || 2.x", "1.2.3"},
+	{"x", "1.2.3"},
+	{"2.*.*", "2.1.3"},
+	{"1.2.*", "1.2.3"},
+	{"1.2.* || 2.*", "2.1.3"},
+	{"1.2.* || 2.*", "1.2.3"},
+	{"2", "2.1.2"},
+	{"2.3", "2.3.1"},
+	{"~0.0.1", "0.0.1"},
+	{"~0.0.1", "0.0.2"},
+	{"~x", "0.0.9"},
+	{"~2", "2.0.9"},
+	{"~2.4", "2.4.0"},
+	{"~2.4", "2.4.5"},
+	{"~>3.2.1", "3.2.2"},
+	{"~1", "1.2.3"},
+	{"~>1", "1.2.3"},
+	{"~> 1", "1.2.3"},
+	{"~1.0", "1.0.2"},
+	{"~ 1.0", "1.0.2"},
+	{"~ 1.0.3", "1.0.12"},
+	{">=1", "1.0.0"},
+	{">= 1", "1.0.0"},
+	{"<1.2", "1.1.1"},
+	{"< 1.2", "1.1.1"},
+	{"~v0.5.4-pre", "0.5.5"},
+	{"~v0.5.4-pre", "0.5.4"},
+	{"=0.7.x", "0.7.2"},
+	{"<=0.7.x", "0.7.2"},
+	{">=0.7.x", "0.7.2"},
+	{"<=0.7.x", "0.6.2"},
+	{"~1.2.1 >=1.2.3", "1.2.3"},
+	{"~1.2.1 =1.2.3", "1.2.3"},
+	{"~1.2.1 1.2.3", "1.2.3"},
+	{"~1.2.1 >=1.2.3 1.2.3", "1.2.3"},
+	{"~1.2.1 1.2.3 >=1.2.3", "1.2.3"},
+	{">=1.2.1 1.2.3", "1.2.3"},
+	{"1.2.3 >=1.2.1", "1.2.3"},
+	{">=1.2.3 >=1.2.1", "1.2.3"},
+	{">=1.2.1 >=1.2.3", "1.2.3"},
+	{">=1.2", "1.2.8"},
+	{"^1.2.3", "1.8.1"},
+	{"^0.1.2", "0.1.2"},
+	{"^0.1", "0.1.2"},
+	{"^0.0.1", "0.0.1"},
+	{"^1.2", "1.4.2"},
+	{"^1.2 ^1", "1.4.2"},
+	{"^1.2.3-alpha", "1.2.3-pre"},
+	{"^1.2.0-alpha", "1.2.0-pre"},
+	{"^0.0.1-alpha", "0.0.1-beta"},
+	{"^0.0.1-alpha", "0.0.1"},
+	{"^0.1.1-alpha", "0.1.1-beta"},
+	{"^x", "1.2.3"},
+	{"x - 1.0.0", "0.9.7"},
+	{"x - 1.x", "0.9.7"},
+	{"1.0.0 - x", "1.9.7"},
+	{"1.x - x", "1.9.7"},
+	{"<=7.x", "7.9.9"},
+	{"1.2.3-alpha.x", "1.2.3-alpha.x"},
+	{">= 14 <19", "18.16.0"},
+	{"14 - 18", "18.16.0"},
+}
 
-	cs, err := p.parse(base)
-	require.NoError(t, err)
-	assert.Len(t, cs, 2)
+var rangeExcludeFixtures = [][2]string{
+	{"1.0.0 - 2.0.0", "2.2.3"},
+	{"1.2.3+asdf - 2.4.3+asdf", "1.2.3-pre.2"},
+	{"1.2.3+asdf - 2.4.3+asdf", "2.4.3-alpha"},
+	{"^1.2.3+build", "2.0.0"},
+	{"^1.2.3+build", "1.2.0"},
+	{"^1.2.3", "1.2.3-pre"},
+	{"^1.2", "1.2.0-pre"},
+	{">1.2", "1.3.0-beta"},
+	{"<=1.2.3", "1.2.3-beta"},
+	{"^1.2.3", "1.2.3-beta"},
+	{"=0.7.x", "0.7.0-asdf"},
+	{">=0.7.x", "0.7.0-asdf"},
+	{"<=0.7.x", "0.7.0-asdf"},
+	{"1.0.0", "1.0.1"},
+	{">=1.0.0", "0.0.0"},
+	{">=1.0.0", "0.0.1"},
+	{">=1.0.0", "0.1.0"},
+	{">1.0.0", "0.0.1"},
+	{">1.0.0", "0.1.0"},
+	{"<=2.0.0", "3.0.0"},
+	{"<=2.0.0", "2.9999.9999"},
+	{"<=2.0.0", "2.2.9"},
+	{"<2.0.0", "2.9999.9999"},
+	{"<2.0.0", "2.2.9"},
+	{">=0.1.97", "0.1.93"},
+	{"0.1.20 || 1.2.4", "1.2.3"},
+	{">=0.2.3 || <0.0.1", "0.0.3"},
+	{">=0.2.3 || <0.0.1", "0.2.2"},
+	{"2.x.x", "1.1.3"},
+	{"2.x.x", "3.1.3"},
+	{"1.2.x", "1.3.3"},
+	{"1.2.x || 2.x", "3.1.3"},
+	{"1.2.x || 2.x", "1.1.3"},
+	{"2.*.*", "1.1.3"},
+	{"2.*.*", "3.1.3"},
+	{"1.2.*", "1.3.3"},
+	{"1.2.* || 2.*", "3.1.3"},
+	{"1.2.* || 2.*", "1.1.3"},
+	{"2", "1.1.2"},
+	{"2.3", "2.4.1"},
+	{"~0.0.1", "0.1.0-alpha"},
+	{"~0.0.1", "0.1.0"},
+	{"~2.4", "2.5.0"},
+	{"~2.4", "2.3.9"},
+	{"~>3.2.1", "3.3.2"},
+	{"~>3.2.1", "3.2.0"},
+	{"~1", "0.2.3"},
+	{"~>1", "2.2.3"},
+	{"~1.0", "1.1.0"},
+	{"<1", "1.0.0"},
+	{">=1.2", "1.1.1"},
+	{"~v0.5.4-beta", "0.5.4-alpha"},
+	{"=0.7.x", "0.8.2"},
+	{">=0.7.x", "0.6.2"},
+	{"<0.7.x", "0.7.2"},
+	{"<1.2.3", "1.2.3-beta"},
+	{"=1.2.3", "1.2.3-beta"},
+	{">1.2", "1.2.8"},
+	{"^0.0.1", "0.0.2-alpha"},
+	{"^0.0.1", "0.0.2"},
+	{"^1.2.3", "2.0.0-alpha"},
+	{"^1.2.3", "1.2.2"},
+	{"^1.2", "1.1.9"},
+	{"^1.0.0", "2.0.0-rc1"},
+	{"1 - 2", "3.0.0-pre"},
+	{"1 - 2", "2.0.0-pre"},
+	{"1 - 2", "1.0.0-pre"},
+	{"1.0 - 2", "1.0.0-pre"},
+	{"1.1.x", "1.0.0-a"},
+	{"1.1.x", "1.1.0-a"},
+	{"1.1.x", "1.2.0-a"},
+	{"1.x", "1.0.0-a"},
+	{"1.x", "1.1.0-a"},
+	{"1.x", "1.2.0-a"},
+	{">=1.0.0 <1.1.0", "1.1.0"},
+	{">=1.0.0 <1.1.0", "1.1.0-pre"},
+	{">=1.0.0 <1.1.0-pre", "1.1.0-pre"},
+	{">1.2.3", "1.2.3"},
+	{"<*", "1.2.3"},
+	{">*", "1.2.3"},
+	{">= 14 <19", "19.0.0"},
+	{"14 - 18", "19.0.0"},
+	{"1.2.3-alpha.x", "1.2.3-alpha.y"},
+}
 
-	c1, c2 := cs[0], cs[1]
+func TestParserConformance(t *testing.T) {
+	check := func(t *testing.T, r, v string) bool {
+		cs, err := newParser().parse(r)
+		require.NoError(t, err, "range %q", r)
 
-	checkVersion, err := semver.NewVersion("0.10.4")
+		sv, err := semver.NewVersion(v)
+		require.NoError(t, err, "version %q", v)
 
-	require.NoError(t, err)
-	assert.True(t, c1.Check(checkVersion))
-	assert.True(t, c2.Check(checkVersion))
+		return cs.eval(sv)
+	}
 
-	base = ">=1.2.3-beta.2 ~1.3.0-0"
+	for _, f := range rangeIncludeFixtures {
+		assert.Truef(t, check(t, f[0], f[1]), "%q should satisfy %q", f[1], f[0])
+	}
 
-	p = newParser()
-	cs, err = p.parse(base)
-	assert.NoError(t, err)
+	for _, f := range rangeExcludeFixtures {
+		assert.Falsef(t, check(t, f[0], f[1]), "%q should not satisfy %q", f[1], f[0])
+	}
+}
 
-	rv := "1.2.4"
-	v, err := semver.NewVersion(rv)
+func TestParserPrereleasePrecedence(t *testing.T) {
+	// Numeric identifiers have lower precedence than alphanumeric ones
+	cs, err := newParser().parse(">=1.0.0-alpha.1 <1.0.0-alpha.beta")
 	require.NoError(t, err)
 
-	ok := cs.eval(v)
-	assert.True(t, ok)
+	for _, v := range []string{"1.0.0-alpha.1", "1.0.0-alpha.2", "1.0.0-alpha.10"} {
+		sv, err := semver.NewVersion(v)
+		require.NoError(t, err)
+		assert.Truef(t, cs.eval(sv), "%s", v)
+	}
 
-	base = ">=1.2.3-beta.2 v1.2.4"
+	sv, err := semver.NewVersion("1.0.0-alpha.beta")
+	require.NoError(t, err)
+	assert.False(t, cs.eval(sv))
+}
 
-	p = newParser()
-	cs, err = p.parse(base)
-	assert.NoError(t, err)
+func TestParserErrors(t *testing.T) {
+	tests := map[string]int{
+		"blerg":           1,
+		">=1.2.3 - 2":     1,
+		"1.2.3 -":         7,
+		"^01.2":           2,
+		"1.2.3-":          7,
+		"1.2.3-01":        7,
+		"~1..2":           4,
+		">=<1":            1,
+		">= 14 <1a":       9,
+		"1.2.3.4":         6,
+		"^1.2 || >=":      9,
+		">=1.2.3 <2 foo":  12,
+		"1.2-beta":        4,
+		"1.2.3 - >=2.0.0": 9,
+	}
 
-	rv = "1.2.4"
-	v, err = semver.NewVersion(rv)
+	for input, column := range tests {
+		t.Run(input, func(t *testing.T) {
+			_, err := newParser().parse(input)
+			require.Error(t, err)
+
+			var rErr *rangeError
+			require.ErrorAs(t, err, &rErr)
+			assert.Equal(t, column, rErr.column)
+			assert.ErrorContains(t, err, input)
+		})
+	}
+}
+
+func TestParser(t *testing.T) {
+	base := ">= 0.10.3 < 0.12"
+	p := newParser()
+
+	cs, err := p.parse(base)
 	require.NoError(t, err)
+	require.Len(t, cs, 1)
+	assert.Len(t, cs[0], 2)
 
-	ok = cs.eval(v)
-	assert.True(t, ok)
+	checkVersion, err := semver.NewVersion("0.10.4")
+	require.NoError(t, err)
+	assert.True(t, cs.eval(checkVersion))
+
+	checkVersion, err = semver.NewVersion("0.12.0")
+	require.NoError(t, err)
+	assert.False(t, cs.eval(checkVersion))
 
 	base = ">=1.2.3-beta.2, v1.2.4 || v1.3.0-0"
 
 	p = newParser()
 	cs, err = p.parse(base)
 	assert.NoError(t, err)
+	assert.Len(t, cs, 2)
 
-	rv = "1.2.4"
-	v, err = semver.NewVersion(rv)
+	rv := "1.2.4"
+	v, err := semver.NewVersion(rv)
 	require.NoError(t, err)
 
-	ok = cs.eval(v)
+	ok := cs.eval(v)
 	assert.True(t, ok)
 
 	base = "v1.2.4 || v1.3.0-0, >=1.2.3-beta.2"
@@ -69,11 +305,6 @@ func TestParser(t *testing.T) {
 	cs, err = p.parse(base)
 	assert.NoError(t, err)
 
-	rv = "1.2.4"
-	v, err = semver.NewVersion(rv)
-	require.NoError(t, err)
-
 	ok = cs.eval(v)
 	assert.True(t, ok)
-
 }
@@ -1,8 +1,15 @@
 package plugin
 
 import (
+	"errors"
+	"fmt"
 	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
 
+	"github.com/Masterminds/semver"
 	"github.com/cocov-ci/go-plugin-kit/cocov"
 	"go.uber.org/zap"
 )
@@ -11,15 +18,28 @@ const (
 	npm  = "npm"
 	pnpm = "pnpm"
 	yarn = "yarn"
+	bun  = "bun"
 )
 
 var managers = map[string]string{
-	"pnpm-lock.yaml":    pnpm,
-	"yarn.lock":         yarn,
-	"package-lock.json": npm,
+	"pnpm-lock.yaml":      pnpm,
+	"yarn.lock":           yarn,
+	"package-lock.json":   npm,
+	"npm-shrinkwrap.json": npm,
+	"bun.lockb":           bun,
+	"bun.lock":            bun,
 }
 
 func installPkgManager(ctx cocov.Context, e Exec, nodePath string, repoPath string) (string, string, error) {
+	pkg, err := readPackageJson(ctx, repoPath)
+	if err != nil {
+		return "", "", err
+	}
+
+	if pkg.PackageManager != "" {
+		return activatePkgManager(ctx, e, nodePath, repoPath, pkg.PackageManager)
+	}
+
 	mgr, file, err := findLockFile(ctx, repoPath)
 	if err != nil {
 		return "", "", err
@@ -39,6 +59,156 @@ func installPkgManager(ctx cocov.Context, e Exec, nodePath string, repoPath stri
 	return mgr, file, nil
 }
 
+// packageManagerSpec represents the packageManager field of package.json,
+// such as pnpm@8.6.0+sha512.7a9b.
+type packageManagerSpec struct {
+	name    string
+	version string
+	hash    string
+}
+
+var packageManagerHash = regexp.MustCompile(`^[a-z0-9]+\.[0-9a-fA-F]+$`)
+
+func parsePackageManager(value string) (*packageManagerSpec, error) {
+	name, version, ok := strings.Cut(value, "@")
+	if !ok || name == "" || version == "" {
+		return nil, fmt.Errorf("invalid packageManager %q: expected <name>@<version>", value)
+	}
+
+	switch name {
+	case npm, pnpm, yarn, bun:
+	default:
+		return nil, fmt.Errorf("invalid packageManager %q: unsupported package manager %s", value, name)
+	}
+
+	spec := &packageManagerSpec{name: name}
+	spec.version, spec.hash, _ = strings.Cut(version, "+")
+
+	if _, err := semver.NewVersion(spec.version); err != nil {
+		return nil, fmt.Errorf("invalid packageManager %q: %w", value, err)
+	}
+
+	if spec.hash != "" && !packageManagerHash.MatchString(spec.hash) {
+		return nil, fmt.Errorf("invalid packageManager %q: malformed hash %s", value, spec.hash)
+	}
+
+	return spec, nil
+}
+
+// activatePkgManager uses corepack to activate the exact package manager
+// version declared by the packageManager field. Corepack validates the
+// downloaded package manager against the hash present in the field. Bun is
+// not supported by corepack, and is installed through npm instead.
+func activatePkgManager(ctx cocov.Context, e Exec, nodePath, repoPath, value string) (string, string, error) {
+	spec, err := parsePackageManager(value)
+	if err != nil {
+		ctx.L().Error("failed to parse packageManager", zap.Error(err))
+		return "", "", err
+	}
+
+	if spec.name == bun {
+		err = installBun(ctx, e, nodePath, spec)
+	} else {
+		err = corepackActivate(ctx, e, nodePath, repoPath, value, spec)
+	}
+
+	if err != nil {
+		return "", "", err
+	}
+
+	file, err := findManagerLockFile(repoPath, spec.name)
+	if err != nil {
+		ctx.L().Error("error looking for lockfile", zap.Error(err))
+		return "", "", err
+	}
+
+	if file == "" {
+		ctx.L().Warn("lock file not found for package manager",
+			zap.String("package manager", spec.name),
+			zap.String("path", repoPath),
+		)
+	}
+
+	return spec.name, file, nil
+}
+
+func corepackActivate(ctx cocov.Context, e Exec, nodePath, repoPath, value string, spec *packageManagerSpec) error {
+	ctx.L().Info("activating package manager through corepack",
+		zap.String("package manager", value),
+	)
+
+	envs := map[string]string{
+		"PATH":                            nodePath,
+		"COREPACK_ENABLE_DOWNLOAD_PROMPT": "0",
+	}
+	opts := &cocov.ExecOpts{Workdir: repoPath, Env: envs}
+
+	for _, args := range [][]string{
+		{"enable", spec.name},
+		{"prepare", value, "--activate"},
+	} {
+		stdOut, stdErr, err := e.Exec2("corepack", args, opts)
+		if err != nil {
+			ctx.L().Error("failed to activate package manager",
+				zap.Strings("args", args),
+				zap.String("std out", string(stdOut)),
+				zap.String("std err", string(stdErr)),
+				zap.Error(err),
+			)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func installBun(ctx cocov.Context, e Exec, nodePath string, spec *packageManagerSpec) error {
+	ctx.L().Info("installing package manager through npm",
+		zap.String("package manager", spec.name),
+		zap.String("version", spec.version),
+	)
+
+	if spec.hash != "" {
+		ctx.L().Warn("hash of packageManager is not verified for bun",
+			zap.String("hash", spec.hash),
+		)
+	}
+
+	opts := &cocov.ExecOpts{Env: map[string]string{"PATH": nodePath}}
+	_, err := e.Exec(npm, []string{"install", "-g", bun + "@" + spec.version}, opts)
+	if err != nil {
+		ctx.L().Error("failed to install manager", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// findManagerLockFile returns the name of the lockfile used by mgr present in
+// repoPath, or an empty string in case there is none.
+func findManagerLockFile(repoPath, mgr string) (string, error) {
+	files := make([]string, 0, len(managers))
+	for file, m := range managers {
+		if m == mgr {
+			files = append(files, file)
+		}
+	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		_, err := os.Stat(filepath.Join(repoPath, file))
+		if err == nil {
+			return file, nil
+		}
+
+		if !errors.Is(err, os.ErrNotExist) {
+			return "", err
+		}
+	}
+
+	return "", nil
+}
+
 func findLockFile(ctx cocov.Context, repoPath string) (string, string, error) {
 	entries, err := os.ReadDir(repoPath)
 	if err != nil {
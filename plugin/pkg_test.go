@@ -1,10 +1,14 @@
 package plugin
 
 import (
+	"errors"
+	"os"
 	"path/filepath"
 	"testing"
 
+	"github.com/cocov-ci/go-plugin-kit/cocov"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestInstallPkgManager(t *testing.T) {
@@ -40,4 +44,124 @@ func TestInstallPkgManager(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, mgr, pnpm)
 	})
+
+	for file, expected := range map[string]string{
+		"bun.lockb":           bun,
+		"bun.lock":            bun,
+		"npm-shrinkwrap.json": npm,
+	} {
+		t.Run("Founds "+file, func(t *testing.T) {
+			dir := t.TempDir()
+			require.NoError(t, os.WriteFile(filepath.Join(dir, file), nil, os.ModePerm))
+
+			helper := newTestHelper(t)
+			mgr, lockFile, err := findLockFile(helper.ctx, dir)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, mgr)
+			assert.Equal(t, file, lockFile)
+		})
+	}
+}
+
+func TestParsePackageManager(t *testing.T) {
+	t.Run("Parses valid values", func(t *testing.T) {
+		spec, err := parsePackageManager("pnpm@8.6.0+sha512.7a9b0c1d")
+		require.NoError(t, err)
+		assert.Equal(t, &packageManagerSpec{name: pnpm, version: "8.6.0", hash: "sha512.7a9b0c1d"}, spec)
+
+		spec, err = parsePackageManager("yarn@3.6.1")
+		require.NoError(t, err)
+		assert.Equal(t, &packageManagerSpec{name: yarn, version: "3.6.1"}, spec)
+
+		spec, err = parsePackageManager("bun@1.1.0")
+		require.NoError(t, err)
+		assert.Equal(t, &packageManagerSpec{name: bun, version: "1.1.0"}, spec)
+	})
+
+	for _, value := range []string{"pnpm", "pnpm@", "bower@1.8.14", "yarn@latest", "npm@9.0.0+sha512"} {
+		t.Run(value, func(t *testing.T) {
+			_, err := parsePackageManager(value)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestActivatePkgManager(t *testing.T) {
+	np := "node-path"
+	value := "pnpm@8.6.0+sha512.7a9b0c1d"
+
+	writePackage := func(t *testing.T, lockFile string) string {
+		dir := t.TempDir()
+		data := []byte(`{"packageManager": "` + value + `"}`)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, pkgJson), data, os.ModePerm))
+		if lockFile != "" {
+			require.NoError(t, os.WriteFile(filepath.Join(dir, lockFile), nil, os.ModePerm))
+		}
+		return dir
+	}
+
+	execOpts := func(dir string) *cocov.ExecOpts {
+		return &cocov.ExecOpts{Workdir: dir, Env: map[string]string{
+			"PATH":                            np,
+			"COREPACK_ENABLE_DOWNLOAD_PROMPT": "0",
+		}}
+	}
+
+	t.Run("Activates the declared version", func(t *testing.T) {
+		dir := writePackage(t, "pnpm-lock.yaml")
+		helper := newTestHelper(t)
+		opts := execOpts(dir)
+
+		helper.exec.EXPECT().Exec2("corepack", []string{"enable", pnpm}, opts)
+		helper.exec.EXPECT().Exec2("corepack", []string{"prepare", value, "--activate"}, opts)
+
+		mgr, file, err := installPkgManager(helper.ctx, helper.exec, np, dir)
+		require.NoError(t, err)
+		assert.Equal(t, pnpm, mgr)
+		assert.Equal(t, "pnpm-lock.yaml", file)
+	})
+
+	t.Run("Works without a lockfile", func(t *testing.T) {
+		dir := writePackage(t, "")
+		helper := newTestHelper(t)
+		opts := execOpts(dir)
+
+		helper.exec.EXPECT().Exec2("corepack", []string{"enable", pnpm}, opts)
+		helper.exec.EXPECT().Exec2("corepack", []string{"prepare", value, "--activate"}, opts)
+
+		mgr, file, err := installPkgManager(helper.ctx, helper.exec, np, dir)
+		require.NoError(t, err)
+		assert.Equal(t, pnpm, mgr)
+		assert.Empty(t, file)
+	})
+
+	t.Run("Fails when corepack rejects the package manager", func(t *testing.T) {
+		dir := writePackage(t, "pnpm-lock.yaml")
+		helper := newTestHelper(t)
+		opts := execOpts(dir)
+
+		helper.exec.EXPECT().Exec2("corepack", []string{"enable", pnpm}, opts)
+		helper.exec.EXPECT().
+			Exec2("corepack", []string{"prepare", value, "--activate"}, opts).
+			Return(nil, []byte("Mismatch hashes"), errors.New("exit status 1"))
+
+		_, _, err := installPkgManager(helper.ctx, helper.exec, np, dir)
+		assert.Error(t, err)
+	})
+
+	t.Run("Installs bun through npm", func(t *testing.T) {
+		dir := t.TempDir()
+		data := []byte(`{"packageManager": "bun@1.1.0"}`)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, pkgJson), data, os.ModePerm))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "bun.lockb"), nil, os.ModePerm))
+
+		helper := newTestHelper(t)
+		opts := &cocov.ExecOpts{Env: map[string]string{"PATH": np}}
+		helper.exec.EXPECT().Exec(npm, []string{"install", "-g", "bun@1.1.0"}, opts)
+
+		mgr, file, err := installPkgManager(helper.ctx, helper.exec, np, dir)
+		require.NoError(t, err)
+		assert.Equal(t, bun, mgr)
+		assert.Equal(t, "bun.lockb", file)
+	})
 }
@@ -0,0 +1,77 @@
+package plugin
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+const muslSuffix = "-musl"
+
+// nodeArchs maps GOARCH values to the architecture names used by Node
+// distributions.
+var nodeArchs = map[string]string{
+	"amd64":   "x64",
+	"arm64":   "arm64",
+	"arm":     "armv7l",
+	"ppc64le": "ppc64le",
+	"s390x":   "s390x",
+}
+
+// muslLoaderGlob matches the dynamic loader shipped by musl-based systems
+// such as Alpine.
+var muslLoaderGlob = "/lib/ld-musl-*.so.1"
+
+// detectPlatform returns the platform identifier used by Node distributions
+// for the current host, such as linux-x64 or linux-arm64-musl.
+func detectPlatform() (string, error) {
+	if runtime.GOOS != "linux" {
+		return "", fmt.Errorf("unsupported operating system %s", runtime.GOOS)
+	}
+
+	arch, ok := nodeArchs[runtime.GOARCH]
+	if !ok {
+		return "", fmt.Errorf("unsupported architecture %s", runtime.GOARCH)
+	}
+
+	p := "linux-" + arch
+	if isMusl() {
+		p += muslSuffix
+	}
+
+	return p, nil
+}
+
+func isMusl() bool {
+	matches, _ := filepath.Glob(muslLoaderGlob)
+	return len(matches) > 0
+}
+
+func isMuslPlatform(platform string) bool {
+	return strings.HasSuffix(platform, muslSuffix)
+}
+
+// forPlatform returns an index containing only versions providing an archive
+// for the given platform. Versions not listing their files are kept, since
+// their availability cannot be determined.
+func (v *versionIndex) forPlatform(platform string) *versionIndex {
+	filtered := &versionIndex{versions: make([]versionInfo, 0, len(v.versions))}
+	for _, info := range v.versions {
+		if len(info.Files) == 0 || info.hasFile(platform) {
+			filtered.versions = append(filtered.versions, info)
+		}
+	}
+
+	return filtered
+}
+
+func (i versionInfo) hasFile(name string) bool {
+	for _, f := range i.Files {
+		if f == name {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,64 @@
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectPlatform(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("platform detection is only supported on linux")
+	}
+
+	original := muslLoaderGlob
+	t.Cleanup(func() { muslLoaderGlob = original })
+
+	t.Run("Detects glibc", func(t *testing.T) {
+		muslLoaderGlob = filepath.Join(t.TempDir(), "ld-musl-*.so.1")
+
+		p, err := detectPlatform()
+		require.NoError(t, err)
+		assert.Equal(t, "linux-"+nodeArchs[runtime.GOARCH], p)
+		assert.False(t, isMuslPlatform(p))
+	})
+
+	t.Run("Detects musl", func(t *testing.T) {
+		dir := t.TempDir()
+		err := os.WriteFile(filepath.Join(dir, "ld-musl-x86_64.so.1"), nil, os.ModePerm)
+		require.NoError(t, err)
+		muslLoaderGlob = filepath.Join(dir, "ld-musl-*.so.1")
+
+		p, err := detectPlatform()
+		require.NoError(t, err)
+		assert.True(t, strings.HasSuffix(p, "-musl"))
+		assert.True(t, isMuslPlatform(p))
+	})
+}
+
+func TestForPlatform(t *testing.T) {
+	vi := &versionIndex{
+		versions: []versionInfo{
+			{Version: "v20.0.0", Files: []string{"linux-arm64", "linux-x64"}},
+			{Version: "v18.0.0", Files: []string{"linux-x64", "linux-x64-musl"}},
+			{Version: "v16.0.0"},
+		},
+	}
+
+	versions := func(v *versionIndex) []string {
+		var out []string
+		for _, i := range v.versions {
+			out = append(out, i.Version)
+		}
+		return out
+	}
+
+	assert.Equal(t, []string{"v20.0.0", "v16.0.0"}, versions(vi.forPlatform("linux-arm64")))
+	assert.Equal(t, []string{"v18.0.0", "v16.0.0"}, versions(vi.forPlatform("linux-x64-musl")))
+	assert.Equal(t, []string{"v20.0.0", "v18.0.0", "v16.0.0"}, versions(vi.forPlatform("linux-x64")))
+}
@@ -6,18 +6,66 @@ import (
 	"github.com/cocov-ci/go-plugin-kit/cocov"
 )
 
-//go:generate go run ../generator/genrules.go
+//go:generate go run ../generator
+
+const (
+	severityWarning = 1
+	severityError   = 2
+)
 
 type message struct {
-	RuleID  string `json:"ruleId"`
-	Message string `json:"message"`
-	Line    uint   `json:"line"`
-	EndLine uint   `json:"endLine"`
+	RuleID    string `json:"ruleId"`
+	Severity  int    `json:"severity"`
+	Fatal     bool   `json:"fatal"`
+	Message   string `json:"message"`
+	Line      uint   `json:"line"`
+	EndLine   uint   `json:"endLine"`
+	Column    uint   `json:"column"`
+	EndColumn uint   `json:"endColumn"`
+}
+
+func (m message) severityName() string {
+	if m.Severity == severityWarning {
+		return "warning"
+	}
+
+	return "error"
 }
 
 type result struct {
-	FilePath string    `json:"filePath"`
-	Messages []message `json:"messages"`
+	FilePath        string    `json:"filePath"`
+	Messages        []message `json:"messages"`
+	FatalErrorCount int       `json:"fatalErrorCount"`
+}
+
+// issueMessages returns the messages of r to be reported as issues. Files
+// eslint failed to parse are always reported, even when their results lack
+// the fatal message describing the failure.
+func (r result) issueMessages() []message {
+	if r.FatalErrorCount == 0 {
+		return r.Messages
+	}
+
+	for _, m := range r.Messages {
+		if m.Fatal {
+			return r.Messages
+		}
+	}
+
+	return append(r.Messages, message{
+		Severity: severityError,
+		Fatal:    true,
+		Message:  "Parsing error: eslint failed to parse this file",
+		Line:     1,
+	})
+}
+
+// coreRule describes a rule shipped with eslint.
+type coreRule struct {
+	kind       cocov.IssueKind
+	deprecated bool
+	// replacedBy lists the rules superseding a deprecated rule, if any.
+	replacedBy []string
 }
 
 type metadata struct {
@@ -37,7 +85,47 @@ func newCliOutput() *cliOutput {
 	return &cliOutput{Metadata: metadata{RulesMeta: map[string]metadataInfo{}}}
 }
 
+// splitRule splits rule into the prefix of the plugin providing it and its
+// name within the plugin. Scoped plugins are referred to either by their
+// scope alone, as in @typescript-eslint/no-explicit-any, or by their scope
+// and name, as in @next/next/no-img-element.
+func splitRule(rule string) (plugin, name string, ok bool) {
+	parts := strings.SplitN(rule, "/", 3)
+	if len(parts) < 2 {
+		return "", "", false
+	}
+
+	if strings.HasPrefix(rule, "@") && len(parts) == 3 {
+		return parts[0] + "/" + parts[1], parts[2], true
+	}
+
+	return parts[0], strings.Join(parts[1:], "/"), true
+}
+
+// pluginKindForRule returns the kind of rule from the curated tables of
+// plugin rules.
+func pluginKindForRule(rule string) (cocov.IssueKind, bool) {
+	plugin, name, ok := splitRule(rule)
+	if !ok {
+		return 0, false
+	}
+
+	if canonical, ok := pluginAliases[plugin]; ok {
+		plugin = canonical
+	}
+
+	kind, ok := pluginRules[plugin][name]
+	return kind, ok
+}
+
+// kindForRule returns the kind of rule. Rules of known plugins use their
+// curated kinds, others the type reported in the metadata of their run,
+// falling back to the kind of the core rule of the same name.
 func (c *cliOutput) kindForRule(rule string) (cocov.IssueKind, bool) {
+	if kind, ok := pluginKindForRule(rule); ok {
+		return kind, true
+	}
+
 	v, ok := c.Metadata.RulesMeta[rule]
 	if ok {
 		switch v.Type {
@@ -55,10 +143,49 @@ func (c *cliOutput) kindForRule(rule string) (cocov.IssueKind, bool) {
 		rule = split[len(split)-1]
 	}
 
-	kind, ok := rules[rule]
+	r, ok := rules[rule]
 	if ok {
+		return r.kind, true
+	}
+
+	return 0, false
+}
+
+// kindForMessage returns the kind of the issue reported by m, or false in
+// case it must not be reported. Fatal messages are reported as bugs. Other
+// messages go through the ignore list and kind overrides of cfg, and have
+// the warning policy applied to messages of rules configured as "warn".
+// Rules of unknown kind, as well as messages not reported by rules such as
+// unused disable directives, use the default kind of cfg, if any.
+func (c *cliOutput) kindForMessage(m message, policy warningPolicy, cfg *kindConfig) (cocov.IssueKind, bool) {
+	// Fatal messages, such as parsing errors, are not reported by rules.
+	if m.Fatal {
+		return cocov.IssueKindBug, true
+	}
+
+	if cfg.ignores(m.RuleID) {
+		return 0, false
+	}
+
+	if m.Severity == severityWarning && policy.drop {
+		return 0, false
+	}
+
+	if kind, ok := cfg.kindFor(m.RuleID); ok {
+		return kind, true
+	}
+
+	if m.Severity == severityWarning && policy.kind != nil {
+		return *policy.kind, true
+	}
+
+	if kind, ok := c.kindForRule(m.RuleID); ok {
 		return kind, true
 	}
 
+	if cfg.defaultKind != nil {
+		return *cfg.defaultKind, true
+	}
+
 	return 0, false
 }
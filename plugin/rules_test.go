@@ -0,0 +1,166 @@
+package plugin
+
+import (
+	"testing"
+
+	"github.com/cocov-ci/go-plugin-kit/cocov"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKindForMessage(t *testing.T) {
+	out := newCliOutput()
+	out.Metadata.RulesMeta["semi"] = metadataInfo{Type: "layout"}
+
+	warning := message{RuleID: "semi", Severity: severityWarning}
+	failure := message{RuleID: "semi", Severity: severityError}
+
+	t.Run("Includes warnings", func(t *testing.T) {
+		policy, err := warningsPolicy()
+		require.NoError(t, err)
+
+		kind, ok := out.kindForMessage(warning, policy, &kindConfig{})
+		assert.True(t, ok)
+		assert.Equal(t, cocov.IssueKindStyle, kind)
+	})
+
+	t.Run("Drops warnings", func(t *testing.T) {
+		t.Setenv(envWarnings, "drop")
+		policy, err := warningsPolicy()
+		require.NoError(t, err)
+
+		_, ok := out.kindForMessage(warning, policy, &kindConfig{})
+		assert.False(t, ok)
+
+		kind, ok := out.kindForMessage(failure, policy, &kindConfig{})
+		assert.True(t, ok)
+		assert.Equal(t, cocov.IssueKindStyle, kind)
+	})
+
+	t.Run("Maps warnings to a kind", func(t *testing.T) {
+		t.Setenv(envWarnings, "Quality")
+		policy, err := warningsPolicy()
+		require.NoError(t, err)
+
+		kind, ok := out.kindForMessage(warning, policy, &kindConfig{})
+		assert.True(t, ok)
+		assert.Equal(t, cocov.IssueKindQuality, kind)
+
+		kind, ok = out.kindForMessage(failure, policy, &kindConfig{})
+		assert.True(t, ok)
+		assert.Equal(t, cocov.IssueKindStyle, kind)
+	})
+
+	t.Run("Rejects unknown policies", func(t *testing.T) {
+		t.Setenv(envWarnings, "ignore")
+		_, err := warningsPolicy()
+		assert.ErrorContains(t, err, envWarnings)
+	})
+}
+
+func TestFatalMessages(t *testing.T) {
+	out := newCliOutput()
+	policy := warningPolicy{drop: true}
+
+	t.Run("Reports fatal messages as bugs", func(t *testing.T) {
+		m := message{Severity: severityError, Fatal: true, Message: "Parsing error: Unexpected token )", Line: 3}
+		kind, ok := out.kindForMessage(m, policy, &kindConfig{})
+		assert.True(t, ok)
+		assert.Equal(t, cocov.IssueKindBug, kind)
+	})
+
+	t.Run("Applies the default kind to other messages without rules", func(t *testing.T) {
+		m := message{Severity: severityError, Message: "Unused eslint-disable directive (no problems were reported).", Line: 3}
+		_, ok := out.kindForMessage(m, policy, &kindConfig{})
+		assert.False(t, ok)
+
+		quality := cocov.IssueKindQuality
+		kind, ok := out.kindForMessage(m, policy, &kindConfig{defaultKind: &quality})
+		assert.True(t, ok)
+		assert.Equal(t, cocov.IssueKindQuality, kind)
+	})
+
+	t.Run("Keeps reported fatal messages", func(t *testing.T) {
+		res := result{
+			FatalErrorCount: 1,
+			Messages:        []message{{Severity: severityError, Fatal: true, Message: "Parsing error", Line: 3}},
+		}
+		assert.Equal(t, res.Messages, res.issueMessages())
+	})
+
+	t.Run("Reports results with fatal errors", func(t *testing.T) {
+		res := result{FatalErrorCount: 1}
+		messages := res.issueMessages()
+		require.Len(t, messages, 1)
+		assert.True(t, messages[0].Fatal)
+		assert.Equal(t, uint(1), messages[0].Line)
+	})
+}
+
+func TestKindForRule(t *testing.T) {
+	out := newCliOutput()
+	out.Metadata.RulesMeta["security/detect-object-injection"] = metadataInfo{Type: "problem"}
+	out.Metadata.RulesMeta["custom/no-foo"] = metadataInfo{Type: "suggestion"}
+
+	tests := []struct {
+		rule string
+		kind cocov.IssueKind
+		ok   bool
+	}{
+		{"security/detect-object-injection", cocov.IssueKindSecurity, true},
+		{"react/no-danger", cocov.IssueKindSecurity, true},
+		{"@typescript-eslint/no-floating-promises", cocov.IssueKindBug, true},
+		{"sonarjs/cognitive-complexity", cocov.IssueKindComplexity, true},
+		{"sonarjs/no-duplicate-string", cocov.IssueKindDuplication, true},
+		{"unicorn/prefer-set-has", cocov.IssueKindPerformance, true},
+		{"n/no-unsupported-features/es-syntax", cocov.IssueKindBug, true},
+		{"node/no-unsupported-features/es-syntax", cocov.IssueKindBug, true},
+		{"import-x/no-duplicates", cocov.IssueKindDuplication, true},
+		{"custom/no-foo", cocov.IssueKindConvention, true},
+		{"custom/semi", cocov.IssueKindStyle, true},
+		{"@next/next/no-img-element", 0, false},
+		{"semi", cocov.IssueKindStyle, true},
+		{"no-eval", cocov.IssueKindSecurity, true},
+		{"no-empty", cocov.IssueKindQuality, true},
+		{"no-debugger", cocov.IssueKindBug, true},
+		{"max-depth", cocov.IssueKindComplexity, true},
+		{"valid-jsdoc", cocov.IssueKindConvention, true},
+		{"custom/unknown", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			kind, ok := out.kindForRule(tt.rule)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.kind, kind)
+		})
+	}
+}
+
+func TestSplitRule(t *testing.T) {
+	tests := []struct {
+		rule, plugin, name string
+		ok                 bool
+	}{
+		{"semi", "", "", false},
+		{"react/jsx-key", "react", "jsx-key", true},
+		{"@typescript-eslint/no-explicit-any", "@typescript-eslint", "no-explicit-any", true},
+		{"@next/next/no-img-element", "@next/next", "no-img-element", true},
+		{"n/prefer-global/buffer", "n", "prefer-global/buffer", true},
+	}
+
+	for _, tt := range tests {
+		plugin, name, ok := splitRule(tt.rule)
+		assert.Equal(t, tt.ok, ok, tt.rule)
+		assert.Equal(t, tt.plugin, plugin, tt.rule)
+		assert.Equal(t, tt.name, name, tt.rule)
+	}
+}
+
+func TestDeprecatedRules(t *testing.T) {
+	assert.False(t, rules["no-debugger"].deprecated)
+	assert.True(t, rules["semi"].deprecated)
+	assert.Empty(t, rules["semi"].replacedBy)
+	assert.True(t, rules["no-new-object"].deprecated)
+	assert.Equal(t, []string{"no-object-constructor"}, rules["no-new-object"].replacedBy)
+}
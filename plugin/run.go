@@ -8,26 +8,56 @@ import (
 )
 
 func Run(ctx cocov.Context) error {
-	out, err := run(ctx)
+	policy, err := warningsPolicy()
 	if err != nil {
+		ctx.L().Error("invalid warnings policy", zap.Error(err))
 		return err
 	}
 
-	sha := ctx.CommitSHA()
+	cfg, err := loadKindConfig(ctx.Workdir())
+	if err != nil {
+		ctx.L().Error("Failed reading kind configuration", zap.Error(err))
+		return err
+	}
+
+	out, err := run(ctx, defaultExec())
+	if err != nil {
+		return err
+	}
+
+	fp := newFingerprinter(ctx.Workdir())
+	deprecated := map[string]bool{}
 	for _, res := range out.Results {
-		for _, m := range res.Messages {
-			kind, ok := out.kindForRule(m.RuleID)
+		path, err := relativePath(ctx.Workdir(), res.FilePath)
+		if err != nil {
+			ctx.L().Warn("Skipping results of file outside of the repository",
+				zap.String("path", res.FilePath),
+				zap.Error(err),
+			)
+			continue
+		}
+
+		for _, m := range res.issueMessages() {
+			kind, ok := out.kindForMessage(m, policy, cfg)
 			if !ok {
 				continue
 			}
 
-			input := fmt.Sprintf(
-				"%s-%d-%s-%s",
-				kind.String(), m.Line, res.FilePath, sha,
-			)
+			if r, ok := rules[m.RuleID]; ok && r.deprecated && !deprecated[m.RuleID] {
+				deprecated[m.RuleID] = true
+				ctx.L().Warn("Rule is deprecated and may be removed from future eslint versions",
+					zap.String("rule", m.RuleID),
+					zap.Strings("replaced by", r.replacedBy),
+				)
+			}
+
+			// Fatal messages lack an end position
+			if m.EndLine < m.Line {
+				m.EndLine, m.EndColumn = m.Line, m.Column
+			}
 
-			id := cocov.SHA1([]byte(input))
-			if err = ctx.EmitIssue(kind, res.FilePath, m.Line, m.EndLine, m.Message, id); err != nil {
+			id := fp.fingerprint(path, m)
+			if err = emitIssue(ctx, kind, path, m, id); err != nil {
 				ctx.L().Error("Error emitting issue", zap.Error(err))
 				return err
 			}
@@ -36,8 +66,18 @@ func Run(ctx cocov.Context) error {
 	return nil
 }
 
-func run(ctx cocov.Context) (*cliOutput, error) {
-	exec := defaultExec()
+// emitIssue reports m through ctx. Issues only carry lines, so columns are
+// appended to the message as line:column positions.
+func emitIssue(ctx cocov.Context, kind cocov.IssueKind, filePath string, m message, uid string) error {
+	msg := m.Message
+	if m.Column > 0 {
+		msg = fmt.Sprintf("%s (%d:%d-%d:%d)", msg, m.Line, m.Column, m.EndLine, m.EndColumn)
+	}
+
+	return ctx.EmitIssue(kind, filePath, m.Line, m.EndLine, msg, uid)
+}
+
+func run(ctx cocov.Context, exec Exec) (*cliOutput, error) {
 	repos, err := findRepositories(ctx.Workdir())
 	if err != nil {
 		ctx.L().Error("Failed looking for repositories", zap.Error(err))
@@ -49,32 +89,75 @@ func run(ctx cocov.Context) (*cliOutput, error) {
 		return nil, errNoPkgJson
 	}
 
+	projects, err := findProjects(ctx.Workdir(), repos)
+	if err != nil {
+		ctx.L().Error("Failed looking for workspaces", zap.Error(err))
+		return nil, err
+	}
+
+	def, err := loadNodeDefault(ctx.Workdir())
+	if err != nil {
+		ctx.L().Error("Failed reading default node version", zap.Error(err))
+		return nil, err
+	}
+
+	// installed holds the package manager of each workspace root whose
+	// dependencies have already been installed.
+	installed := map[string]string{}
+
 	out := newCliOutput()
-	for _, repo := range repos {
-		np, err := installNode(ctx, exec, repo)
-		if err != nil {
+	for _, p := range projects {
+		if p.config, err = findEslintConfig(ctx.Workdir(), p.path); err != nil {
+			ctx.L().Error("Failed looking for eslint configuration", zap.Error(err))
 			return nil, err
 		}
 
-		mgr, file, err := installPkgManager(ctx, exec, np, repo)
+		if p.config == nil {
+			ctx.L().Info("Skipping package without eslint configuration",
+				zap.String("path", p.path),
+			)
+			continue
+		}
+
+		ctx.L().Info("Using eslint configuration",
+			zap.String("path", p.path),
+			zap.String("config", p.config.path),
+			zap.Bool("flat", p.config.flat),
+		)
+
+		np, err := installNode(ctx, exec, p, def)
 		if err != nil {
 			return nil, err
 		}
 
-		if err = restoreNodeModules(ctx, exec, mgr, file, np, repo); err != nil {
-			return nil, err
+		mgr, ok := installed[p.root]
+		if !ok {
+			rootNp, err := rootNodePath(ctx, exec, p, np, def)
+			if err != nil {
+				return nil, err
+			}
+
+			var file string
+			mgr, file, err = installPkgManager(ctx, exec, rootNp, p.root)
+			if err != nil {
+				return nil, err
+			}
+
+			if err = restoreNodeModules(ctx, exec, mgr, file, rootNp, p.root); err != nil {
+				return nil, err
+			}
+			installed[p.root] = mgr
 		}
 
-		repoOutput, err := runEslint(ctx, exec, np, repo)
+		repoOutput, err := runEslint(ctx, exec, mgr, np, p)
 		if err != nil {
 			return nil, err
 		}
 		out.Results = append(out.Results, repoOutput.Results...)
 
-		for k, v := range out.Metadata.RulesMeta {
+		for k, v := range repoOutput.Metadata.RulesMeta {
 			out.Metadata.RulesMeta[k] = v
 		}
-
 	}
 
 	return out, nil
@@ -0,0 +1,84 @@
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cocov-ci/go-plugin-kit/cocov"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmitIssue(t *testing.T) {
+	m := message{Message: "Missing semicolon.", Line: 3, EndLine: 3, Column: 15, EndColumn: 16}
+
+	t.Run("Encodes columns in the message", func(t *testing.T) {
+		helper := newTestHelper(t)
+		helper.ctx.EXPECT().
+			EmitIssue(cocov.IssueKindStyle, "a.js", uint(3), uint(3), "Missing semicolon. (3:15-3:16)", "id")
+
+		require.NoError(t, emitIssue(helper.ctx, cocov.IssueKindStyle, "a.js", m, "id"))
+	})
+
+	t.Run("Keeps messages without columns", func(t *testing.T) {
+		helper := newTestHelper(t)
+		helper.ctx.EXPECT().
+			EmitIssue(cocov.IssueKindBug, "a.js", uint(1), uint(1), "Parsing error", "id")
+
+		fatal := message{Message: "Parsing error", Line: 1, EndLine: 1}
+		require.NoError(t, emitIssue(helper.ctx, cocov.IssueKindBug, "a.js", fatal, "id"))
+	})
+}
+
+func TestRun(t *testing.T) {
+	t.Cleanup(func() {
+		indexMemo = map[string]*versionIndex{}
+		installedNodes = map[string]bool{}
+	})
+
+	index := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(index, "index.json"), nodeIndexFixture(t), os.ModePerm))
+	t.Setenv(envNodeIndexURL, "file://"+filepath.Join(index, "index.json"))
+	t.Setenv(envNodeIndexTTL, "0")
+
+	wd := t.TempDir()
+	files := map[string]string{
+		pkgJson:             `{"engines": {"node": "18.x"}, "devDependencies": {"eslint": "^8.0.0"}}`,
+		"package-lock.json": `{}`,
+		".eslintrc.json":    `{}`,
+	}
+	for name, data := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(wd, name), []byte(data), os.ModePerm))
+	}
+	eslintPath := writeEslintShim(t, wd)
+
+	output := []byte(`{
+		"results": [{"filePath": "` + filepath.Join(wd, "a.js") + `", "messages": [
+			{"ruleId": "custom/no-foo", "severity": 2, "message": "Unexpected foo.", "line": 1}
+		]}],
+		"metadata": {"rulesMeta": {"custom/no-foo": {"type": "problem"}}}
+	}`)
+
+	helper := newTestHelper(t)
+	helper.ctx.EXPECT().Workdir().Return(wd).AnyTimes()
+	helper.ctx.EXPECT().LoadToolCache(gomock.Any(), gomock.Any()).Return(true)
+	helper.exec.EXPECT().
+		Exec2(gomock.Any(), []string{"--version"}, gomock.Any()).
+		Return([]byte("v18.16.0\n"), nil, nil).
+		Times(2)
+	helper.ctx.EXPECT().LoadArtifactCache(gomock.Any(), filepath.Join(wd, "node_modules")).Return(true, nil)
+	helper.exec.EXPECT().Exec2(npm, []string{"install"}, gomock.Any())
+	helper.exec.EXPECT().
+		Exec2(eslintPath, []string{"-f", "json-with-metadata", wd}, gomock.Any()).
+		Return(output, nil, nil)
+
+	out, err := run(helper.ctx, helper.exec)
+	require.NoError(t, err)
+	require.Len(t, out.Results, 1)
+
+	kind, ok := out.kindForRule("custom/no-foo")
+	assert.True(t, ok)
+	assert.Equal(t, cocov.IssueKindBug, kind)
+}
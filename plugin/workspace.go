@@ -0,0 +1,187 @@
+package plugin
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// project is a directory containing a package.json file. Dependencies are
+// installed at its workspace root, which is the project itself when it is
+// not part of a workspace.
+type project struct {
+	path string
+	root string
+
+	// members holds the paths of the workspace members of a root project,
+	// which are linted on their own and ignored when linting the root.
+	members []string
+
+	// config is the eslint configuration used to lint the project.
+	config *eslintConfig
+}
+
+// findProjects groups the provided package directories by the workspace
+// they belong to. Workspace roots are looked up from each directory upwards,
+// without leaving rootPath.
+func findProjects(rootPath string, repos []string) ([]project, error) {
+	projects := make([]project, 0, len(repos))
+	for _, repo := range repos {
+		root, err := findWorkspaceRoot(rootPath, repo)
+		if err != nil {
+			return nil, err
+		}
+
+		projects = append(projects, project{path: repo, root: root})
+	}
+
+	for i := range projects {
+		for _, other := range projects {
+			if other.root == projects[i].path && other.path != projects[i].path {
+				projects[i].members = append(projects[i].members, other.path)
+			}
+		}
+	}
+
+	return projects, nil
+}
+
+// findWorkspaceRoot returns the closest directory among the parents of
+// repoPath, up to rootPath, declaring a workspace repoPath is a member of.
+// repoPath itself is returned in case there is none.
+func findWorkspaceRoot(rootPath, repoPath string) (string, error) {
+	rootPath = filepath.Clean(rootPath)
+	repoPath = filepath.Clean(repoPath)
+
+	for dir := repoPath; ; {
+		patterns, ok, err := workspacePatterns(dir)
+		if err != nil {
+			return "", err
+		}
+
+		if ok && (dir == repoPath || isWorkspaceMember(dir, repoPath, patterns)) {
+			return dir, nil
+		}
+
+		parent := filepath.Dir(dir)
+		if dir == rootPath || parent == dir || !isWithin(rootPath, parent) {
+			return repoPath, nil
+		}
+		dir = parent
+	}
+}
+
+// workspacePatterns returns the member patterns of the workspace declared
+// at dir, if any. Workspaces are declared by the workspaces field of
+// package.json, as used by npm, yarn and bun, or by pnpm-workspace.yaml,
+// lerna.json and nx.json files. Nx workspaces have no patterns, and
+// include every package below them.
+func workspacePatterns(dir string) ([]string, bool, error) {
+	pnpmWs := struct {
+		Packages []string `yaml:"packages"`
+	}{}
+	if ok, err := readWorkspaceFile(filepath.Join(dir, "pnpm-workspace.yaml"), yaml.Unmarshal, &pnpmWs); err != nil || ok {
+		return pnpmWs.Packages, ok, err
+	}
+
+	lerna := struct {
+		Packages []string `json:"packages"`
+	}{}
+	if ok, err := readWorkspaceFile(filepath.Join(dir, "lerna.json"), json.Unmarshal, &lerna); err != nil || ok {
+		if len(lerna.Packages) == 0 {
+			lerna.Packages = []string{"packages/*"}
+		}
+		return lerna.Packages, ok, err
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "nx.json")); err == nil {
+		return nil, true, nil
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, false, err
+	}
+
+	pkg := struct {
+		Workspaces json.RawMessage `json:"workspaces"`
+	}{}
+	ok, err := readWorkspaceFile(filepath.Join(dir, pkgJson), json.Unmarshal, &pkg)
+	if err != nil || !ok || len(pkg.Workspaces) == 0 || string(pkg.Workspaces) == "null" {
+		return nil, false, err
+	}
+
+	// Yarn also accepts an object holding the patterns under packages.
+	var patterns []string
+	if err = json.Unmarshal(pkg.Workspaces, &patterns); err != nil {
+		ws := struct {
+			Packages []string `json:"packages"`
+		}{}
+		if err = json.Unmarshal(pkg.Workspaces, &ws); err != nil {
+			return nil, false, err
+		}
+		patterns = ws.Packages
+	}
+
+	return patterns, true, nil
+}
+
+func readWorkspaceFile(file string, unmarshal func([]byte, any) error, v any) (bool, error) {
+	data, err := os.ReadFile(file)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return true, unmarshal(data, v)
+}
+
+// isWorkspaceMember reports whether repoPath matches the member patterns of
+// the workspace rooted at root. Patterns prefixed by ! exclude packages, and
+// a trailing /** matches any package below a directory.
+func isWorkspaceMember(root, repoPath string, patterns []string) bool {
+	rel, err := filepath.Rel(root, repoPath)
+	if err != nil {
+		return false
+	}
+	rel = filepath.ToSlash(rel)
+
+	if patterns == nil {
+		return true
+	}
+
+	member := false
+	for _, pattern := range patterns {
+		exclude := strings.HasPrefix(pattern, "!")
+		pattern = strings.TrimPrefix(strings.TrimPrefix(pattern, "!"), "./")
+		pattern = strings.TrimSuffix(pattern, "/")
+
+		var ok bool
+		if strings.HasSuffix(pattern, "/**") {
+			ok = strings.HasPrefix(rel, strings.TrimSuffix(pattern, "**"))
+		} else {
+			ok, _ = path.Match(pattern, rel)
+		}
+
+		if ok {
+			member = !exclude
+		}
+	}
+
+	return member
+}
+
+func isWithin(rootPath, path string) bool {
+	rel, err := filepath.Rel(rootPath, path)
+	if err != nil {
+		return false
+	}
+
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
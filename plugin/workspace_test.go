@@ -0,0 +1,104 @@
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeWorkspaceFile(t *testing.T, path, content string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+	require.NoError(t, os.WriteFile(path, []byte(content), os.ModePerm))
+}
+
+func TestFindWorkspaceRoot(t *testing.T) {
+	tests := map[string]struct {
+		file    string
+		content string
+	}{
+		"npm workspaces":  {pkgJson, `{"workspaces": ["packages/*"]}`},
+		"yarn workspaces": {pkgJson, `{"workspaces": {"packages": ["packages/*"]}}`},
+		"pnpm workspace":  {"pnpm-workspace.yaml", "packages:\n  - packages/*\n"},
+		"lerna":           {"lerna.json", `{"packages": ["packages/*"]}`},
+		"nx":              {"nx.json", `{}`},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			root := t.TempDir()
+			member := filepath.Join(root, "packages", "app")
+			writeWorkspaceFile(t, filepath.Join(root, tt.file), tt.content)
+			writeWorkspaceFile(t, filepath.Join(member, pkgJson), `{}`)
+
+			found, err := findWorkspaceRoot(root, member)
+			require.NoError(t, err)
+			assert.Equal(t, root, found)
+		})
+	}
+
+	t.Run("Returns the package outside workspaces", func(t *testing.T) {
+		root := t.TempDir()
+		app := filepath.Join(root, "app")
+		writeWorkspaceFile(t, filepath.Join(root, pkgJson), `{"name": "root"}`)
+		writeWorkspaceFile(t, filepath.Join(app, pkgJson), `{}`)
+
+		found, err := findWorkspaceRoot(root, app)
+		require.NoError(t, err)
+		assert.Equal(t, app, found)
+	})
+
+	t.Run("Does not leave the root path", func(t *testing.T) {
+		parent := t.TempDir()
+		root := filepath.Join(parent, "repo")
+		app := filepath.Join(root, "app")
+		writeWorkspaceFile(t, filepath.Join(parent, "nx.json"), `{}`)
+		writeWorkspaceFile(t, filepath.Join(app, pkgJson), `{}`)
+
+		found, err := findWorkspaceRoot(root, app)
+		require.NoError(t, err)
+		assert.Equal(t, app, found)
+	})
+}
+
+func TestFindProjects(t *testing.T) {
+	root := t.TempDir()
+	app := filepath.Join(root, "packages", "app")
+	lib := filepath.Join(root, "packages", "lib")
+	other := filepath.Join(root, "tools", "other")
+	writeWorkspaceFile(t, filepath.Join(root, pkgJson), `{"workspaces": ["packages/*"]}`)
+	writeWorkspaceFile(t, filepath.Join(app, pkgJson), `{}`)
+	writeWorkspaceFile(t, filepath.Join(lib, pkgJson), `{}`)
+	writeWorkspaceFile(t, filepath.Join(root, "tools", pkgJson), `{}`)
+	writeWorkspaceFile(t, filepath.Join(other, pkgJson), `{}`)
+
+	repos, err := findRepositories(root)
+	require.NoError(t, err)
+
+	projects, err := findProjects(root, repos)
+	require.NoError(t, err)
+
+	byPath := map[string]project{}
+	for _, p := range projects {
+		byPath[p.path] = p
+	}
+
+	assert.Equal(t, []string{app, lib}, byPath[root].members)
+	assert.Equal(t, root, byPath[app].root)
+	assert.Equal(t, root, byPath[lib].root)
+	assert.Equal(t, other, byPath[other].root)
+	assert.Empty(t, byPath[other].members)
+}
+
+func TestIsWorkspaceMember(t *testing.T) {
+	root := "/repo"
+	patterns := []string{"packages/*", "apps/**", "!packages/legacy"}
+
+	assert.True(t, isWorkspaceMember(root, "/repo/packages/app", patterns))
+	assert.True(t, isWorkspaceMember(root, "/repo/apps/web/admin", patterns))
+	assert.False(t, isWorkspaceMember(root, "/repo/packages/legacy", patterns))
+	assert.False(t, isWorkspaceMember(root, "/repo/tools/other", patterns))
+	assert.True(t, isWorkspaceMember(root, "/repo/tools/other", nil))
+}
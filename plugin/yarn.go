@@ -0,0 +1,48 @@
+package plugin
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+const yarnrcFile = ".yarnrc.yml"
+
+type yarnrc struct {
+	NodeLinker string `yaml:"nodeLinker"`
+}
+
+// readYarnrc reads the .yarnrc.yml file present in repoPath, used by yarn 2
+// and newer. It returns nil in case the file does not exist.
+func readYarnrc(repoPath string) (*yarnrc, error) {
+	data, err := os.ReadFile(filepath.Join(repoPath, yarnrcFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	rc := yarnrc{}
+	if err = yaml.Unmarshal(data, &rc); err != nil {
+		return nil, err
+	}
+
+	return &rc, nil
+}
+
+// usesPnP reports whether the yarn project at repoPath installs its
+// dependencies through Plug'n'Play, which is the default linker of yarn 2
+// and newer, instead of a node_modules directory.
+func usesPnP(repoPath string) bool {
+	rc, err := readYarnrc(repoPath)
+	if err != nil || rc == nil {
+		return false
+	}
+
+	return rc.NodeLinker == "" || rc.NodeLinker == "pnp"
+}